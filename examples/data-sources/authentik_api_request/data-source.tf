@@ -0,0 +1,17 @@
+# To get information which isn't available with other data sources
+
+data "authentik_api_request" "system" {
+  path = "/admin/system/"
+}
+
+# Then use `data.authentik_api_request.system.response_map.runtime` or
+# `jsondecode(data.authentik_api_request.system.response).runtime.python_version`
+
+data "authentik_api_request" "oauth2_providers" {
+  path = "/providers/oauth2/"
+  query = {
+    ordering = "name"
+  }
+}
+
+# Then use `jsondecode(data.authentik_api_request.oauth2_providers.response).results`
@@ -3,4 +3,14 @@ provider "authentik" {
   token = "foo-bar"
   # Optionally set insecure to ignore TLS Certificates
   # insecure = true
+  # Optionally set attributes on all users, groups and tenants
+  # default_attributes = {
+  #   managed-by = "terraform"
+  # }
+  # Optionally take existing objects with the same slug or name into the state, instead of failing
+  # adopt_existing = true
+  # Optionally stamp users, groups and tenants with this workspace, and don't change those of other workspaces
+  # ownership_key = "identity-prod"
+  # API lookups are cached for 30 seconds, optionally disable the cache to always see changes made outside of Terraform
+  # cache_ttl = 0
 }
@@ -0,0 +1,4 @@
+# Import by ID
+terraform import authentik_application.name <slug>
+# Import by slug
+terraform import authentik_application.name slug:<slug>
@@ -0,0 +1,4 @@
+# Import by ID
+terraform import authentik_blueprint.name <id>
+# Import by name
+terraform import authentik_blueprint.name name:<name>
@@ -0,0 +1,4 @@
+# Import by ID
+terraform import authentik_certificate_key_pair.name <id>
+# Import by name
+terraform import authentik_certificate_key_pair.name name:<name>
@@ -0,0 +1,4 @@
+# Import by ID
+terraform import authentik_event_rule.name <id>
+# Import by name
+terraform import authentik_event_rule.name name:<name>
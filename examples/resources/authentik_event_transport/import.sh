@@ -0,0 +1,4 @@
+# Import by ID
+terraform import authentik_event_transport.name <id>
+# Import by name
+terraform import authentik_event_transport.name name:<name>
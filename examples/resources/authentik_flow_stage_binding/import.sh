@@ -0,0 +1,4 @@
+# Import by ID
+terraform import authentik_flow_stage_binding.name <id>
+# Import by the target and order of the binding
+terraform import authentik_flow_stage_binding.name target:<uuid>/order:<order>
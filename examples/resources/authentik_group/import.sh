@@ -0,0 +1,4 @@
+# Import by ID
+terraform import authentik_group.name <id>
+# Import by name
+terraform import authentik_group.name name:<name>
@@ -0,0 +1,2 @@
+# Import by the object's URL, relative to /api/v3
+terraform import authentik_object.role /rbac/roles/<id>
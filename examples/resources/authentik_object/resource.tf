@@ -0,0 +1,8 @@
+# Create an object of an API endpoint that isn't supported by a dedicated resource
+
+resource "authentik_object" "role" {
+  path = "/rbac/roles/"
+  body = jsonencode({
+    name = "auditors"
+  })
+}
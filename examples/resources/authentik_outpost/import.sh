@@ -0,0 +1,4 @@
+# Import by ID
+terraform import authentik_outpost.name <id>
+# Import by name
+terraform import authentik_outpost.name name:<name>
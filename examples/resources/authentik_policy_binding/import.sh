@@ -0,0 +1,4 @@
+# Import by ID
+terraform import authentik_policy_binding.name <id>
+# Import by the target and order of the binding
+terraform import authentik_policy_binding.name target:<uuid>/order:<order>
@@ -0,0 +1,4 @@
+# Import by ID
+terraform import authentik_policy_event_matcher.name <id>
+# Import by name
+terraform import authentik_policy_event_matcher.name name:<name>
@@ -0,0 +1,4 @@
+# Import by ID
+terraform import authentik_property_mapping_saml.name <id>
+# Import by name
+terraform import authentik_property_mapping_saml.name name:<name>
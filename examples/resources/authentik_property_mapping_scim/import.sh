@@ -0,0 +1,4 @@
+# Import by ID
+terraform import authentik_property_mapping_scim.name <id>
+# Import by name
+terraform import authentik_property_mapping_scim.name name:<name>
@@ -0,0 +1,4 @@
+# Import by ID
+terraform import authentik_provider_proxy.name <id>
+# Import by name
+terraform import authentik_provider_proxy.name name:<name>
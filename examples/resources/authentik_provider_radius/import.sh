@@ -0,0 +1,4 @@
+# Import by ID
+terraform import authentik_provider_radius.name <id>
+# Import by name
+terraform import authentik_provider_radius.name name:<name>
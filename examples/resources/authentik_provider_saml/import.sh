@@ -0,0 +1,4 @@
+# Import by ID
+terraform import authentik_provider_saml.name <id>
+# Import by name
+terraform import authentik_provider_saml.name name:<name>
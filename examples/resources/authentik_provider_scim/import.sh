@@ -0,0 +1,4 @@
+# Import by ID
+terraform import authentik_provider_scim.name <id>
+# Import by name
+terraform import authentik_provider_scim.name name:<name>
@@ -0,0 +1,4 @@
+# Import by ID
+terraform import authentik_scope_mapping.name <id>
+# Import by name
+terraform import authentik_scope_mapping.name name:<name>
@@ -0,0 +1,4 @@
+# Import by ID
+terraform import authentik_service_connection_docker.name <id>
+# Import by name
+terraform import authentik_service_connection_docker.name name:<name>
@@ -0,0 +1,4 @@
+# Import by ID
+terraform import authentik_source_oauth.name <slug>
+# Import by slug
+terraform import authentik_source_oauth.name slug:<slug>
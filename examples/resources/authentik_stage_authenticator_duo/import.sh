@@ -0,0 +1,4 @@
+# Import by ID
+terraform import authentik_stage_authenticator_duo.name <id>
+# Import by name
+terraform import authentik_stage_authenticator_duo.name name:<name>
@@ -0,0 +1,4 @@
+# Import by ID
+terraform import authentik_stage_authenticator_static.name <id>
+# Import by name
+terraform import authentik_stage_authenticator_static.name name:<name>
@@ -0,0 +1,4 @@
+# Import by ID
+terraform import authentik_stage_authenticator_validate.name <id>
+# Import by name
+terraform import authentik_stage_authenticator_validate.name name:<name>
@@ -0,0 +1,4 @@
+# Import by ID
+terraform import authentik_stage_authenticator_webauthn.name <id>
+# Import by name
+terraform import authentik_stage_authenticator_webauthn.name name:<name>
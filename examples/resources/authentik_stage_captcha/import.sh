@@ -0,0 +1,4 @@
+# Import by ID
+terraform import authentik_stage_captcha.name <id>
+# Import by name
+terraform import authentik_stage_captcha.name name:<name>
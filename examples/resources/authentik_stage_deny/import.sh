@@ -0,0 +1,4 @@
+# Import by ID
+terraform import authentik_stage_deny.name <id>
+# Import by name
+terraform import authentik_stage_deny.name name:<name>
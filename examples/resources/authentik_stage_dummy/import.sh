@@ -0,0 +1,4 @@
+# Import by ID
+terraform import authentik_stage_dummy.name <id>
+# Import by name
+terraform import authentik_stage_dummy.name name:<name>
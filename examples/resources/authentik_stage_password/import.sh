@@ -0,0 +1,4 @@
+# Import by ID
+terraform import authentik_stage_password.name <id>
+# Import by name
+terraform import authentik_stage_password.name name:<name>
@@ -0,0 +1,4 @@
+# Import by ID
+terraform import authentik_tenant.name <id>
+# Import by domain
+terraform import authentik_tenant.name domain:<domain>
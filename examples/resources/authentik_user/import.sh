@@ -0,0 +1,4 @@
+# Import by ID
+terraform import authentik_user.name <id>
+# Import by username
+terraform import authentik_user.name username:<username>
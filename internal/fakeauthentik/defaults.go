@@ -0,0 +1,384 @@
+package fakeauthentik
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// collections Collections of the authentik API used by the provider
+var collections = map[string]Collection{
+	"/core/applications/": {
+		Lookup:  "slug",
+		Unique:  []string{"slug"},
+		Actions: map[string]ActionFunc{"set_icon_url": actionSetURL("meta_icon")},
+	},
+	"/core/groups/":                             {Unique: []string{"name"}},
+	"/core/tenants/":                            {PK: "tenant_uuid", Unique: []string{"domain"}},
+	"/core/tokens/":                             {Lookup: "identifier", Unique: []string{"identifier"}, Actions: map[string]ActionFunc{"view_key": actionViewKey}},
+	"/core/users/":                              {IntPK: true, Unique: []string{"username"}, Actions: map[string]ActionFunc{"set_password": actionSetPassword}, Computed: computeUser},
+	"/crypto/certificatekeypairs/":              {Unique: []string{"name"}, Actions: map[string]ActionFunc{"view_certificate": actionViewField("certificate_data"), "view_private_key": actionViewField("key_data")}, Computed: computeCertificateKeyPair},
+	"/enterprise/license/":                      {PK: "license_uuid"},
+	"/events/rules/":                            {Unique: []string{"name"}},
+	"/events/transports/":                       {Unique: []string{"name"}},
+	"/flows/bindings/":                          {},
+	"/flows/instances/":                         {Lookup: "slug", Unique: []string{"slug"}, Actions: map[string]ActionFunc{"set_background_url": actionSetURL("background")}},
+	"/managed/blueprints/":                      {Unique: []string{"name"}},
+	"/outposts/instances/":                      {Unique: []string{"name"}},
+	"/outposts/service_connections/docker/":     {Unique: []string{"name"}},
+	"/outposts/service_connections/kubernetes/": {Unique: []string{"name"}},
+	"/policies/bindings/":                       {},
+	"/policies/dummy/":                          {Unique: []string{"name"}},
+	"/policies/event_matcher/":                  {Unique: []string{"name"}},
+	"/policies/expression/":                     {Unique: []string{"name"}},
+	"/policies/password/":                       {Unique: []string{"name"}},
+	"/policies/password_expiry/":                {Unique: []string{"name"}},
+	"/policies/reputation/":                     {Unique: []string{"name"}},
+	"/propertymappings/ldap/":                   {},
+	"/propertymappings/notification/":           {},
+	"/propertymappings/saml/":                   {},
+	"/propertymappings/scim/":                   {},
+	"/propertymappings/scope/":                  {},
+	"/providers/ldap/":                          {IntPK: true, Unique: []string{"name"}},
+	"/providers/oauth2/":                        {IntPK: true, Unique: []string{"name"}, Actions: map[string]ActionFunc{"setup_urls": actionOAuth2SetupURLs}},
+	"/providers/proxy/":                         {IntPK: true, Unique: []string{"name"}},
+	"/providers/radius/":                        {IntPK: true, Unique: []string{"name"}},
+	"/providers/saml/":                          {IntPK: true, Unique: []string{"name"}, Actions: map[string]ActionFunc{"metadata": actionSAMLMetadata}},
+	"/providers/scim/":                          {IntPK: true, Unique: []string{"name"}},
+	"/sources/ldap/":                            {Lookup: "slug", Unique: []string{"slug"}},
+	"/sources/oauth/":                           {Lookup: "slug", Unique: []string{"slug"}},
+	"/sources/plex/":                            {Lookup: "slug", Unique: []string{"slug"}},
+	"/sources/saml/":                            {Lookup: "slug", Unique: []string{"slug"}, Actions: map[string]ActionFunc{"metadata": actionSAMLMetadata}},
+	"/stages/authenticator/duo/":                {Unique: []string{"name"}},
+	"/stages/authenticator/sms/":                {Unique: []string{"name"}},
+	"/stages/authenticator/static/":             {Unique: []string{"name"}},
+	"/stages/authenticator/totp/":               {Unique: []string{"name"}},
+	"/stages/authenticator/validate/":           {Unique: []string{"name"}},
+	"/stages/authenticator/webauthn/":           {Unique: []string{"name"}},
+	"/stages/captcha/":                          {Unique: []string{"name"}},
+	"/stages/consent/":                          {Unique: []string{"name"}},
+	"/stages/deny/":                             {Unique: []string{"name"}},
+	"/stages/dummy/":                            {Unique: []string{"name"}},
+	"/stages/email/":                            {Unique: []string{"name"}},
+	"/stages/identification/":                   {Unique: []string{"name"}},
+	"/stages/invitation/stages/":                {Unique: []string{"name"}},
+	"/stages/password/":                         {Unique: []string{"name"}},
+	"/stages/prompt/prompts/":                   {Unique: []string{"name"}},
+	"/stages/prompt/stages/":                    {Unique: []string{"name"}},
+	"/stages/user_delete/":                      {Unique: []string{"name"}},
+	"/stages/user_login/":                       {Unique: []string{"name"}},
+	"/stages/user_logout/":                      {Unique: []string{"name"}},
+	"/stages/user_write/":                       {Unique: []string{"name"}},
+}
+
+// New Create a fake API with all collections used by the provider, seeded with the objects
+// authentik creates by default, like the `akadmin` user and the default flows
+func New() *Server {
+	s := NewServer()
+	for path, c := range collections {
+		s.Register(path, c)
+	}
+	// Listing endpoints across all types
+	for _, prefix := range []string{"/propertymappings/", "/providers/", "/sources/", "/stages/"} {
+		includes := []string{}
+		for path := range collections {
+			if strings.HasPrefix(path, prefix) {
+				includes = append(includes, path)
+			}
+		}
+		c := Collection{Includes: includes}
+		if prefix == "/sources/" {
+			c.Lookup = "slug"
+		}
+		s.Register(prefix+"all/", c)
+	}
+	s.RegisterSingleton("/admin/version/", func(s *Server, r *http.Request) (int, interface{}) {
+		return http.StatusOK, map[string]interface{}{
+			"version_current": s.Version,
+			"version_latest":  s.Version,
+			"build_hash":      "",
+			"outdated":        false,
+		}
+	})
+	s.RegisterSingleton("/outposts/instances/default_settings/", func(s *Server, r *http.Request) (int, interface{}) {
+		return http.StatusOK, map[string]interface{}{
+			"config": map[string]interface{}{
+				"log_level":                      "info",
+				"authentik_host":                 "",
+				"authentik_host_insecure":        false,
+				"object_naming_template":         "ak-outpost-%(name)s",
+				"refresh_interval":               "minutes=5",
+				"kubernetes_replicas":            1,
+				"kubernetes_namespace":           "authentik",
+				"kubernetes_disabled_components": []string{},
+			},
+		}
+	})
+	s.RegisterSingleton("/root/config/", func(s *Server, r *http.Request) (int, interface{}) {
+		return http.StatusOK, map[string]interface{}{
+			"error_reporting": map[string]interface{}{
+				"enabled":            false,
+				"sentry_dsn":         "",
+				"environment":        "",
+				"send_pii":           false,
+				"traces_sample_rate": 0,
+			},
+			"capabilities": []string{},
+		}
+	})
+	s.seed()
+	return s
+}
+
+// seed Create the objects authentik creates by default
+func (s *Server) seed() {
+	admins := s.Add("/core/groups/", Object{
+		"name":         "authentik Admins",
+		"is_superuser": true,
+		"parent":       nil,
+		"attributes":   map[string]interface{}{},
+	})
+	akadmin := s.Add("/core/users/", Object{
+		"username":   "akadmin",
+		"name":       "authentik Default Admin",
+		"email":      "root@localhost",
+		"is_active":  true,
+		"path":       "users",
+		"type":       "internal",
+		"attributes": map[string]interface{}{},
+		"groups":     []interface{}{admins["pk"]},
+	})
+	admins["users"] = []interface{}{akadmin["pk"]}
+
+	flows := map[string]Object{}
+	for slug, flow := range map[string][2]string{
+		"default-authentication-flow":                     {"authentication", "require_unauthenticated"},
+		"default-invalidation-flow":                       {"invalidation", "none"},
+		"default-provider-authorization-explicit-consent": {"authorization", "require_authenticated"},
+		"default-provider-authorization-implicit-consent": {"authorization", "require_authenticated"},
+		"default-source-authentication":                   {"authentication", "require_unauthenticated"},
+		"default-source-enrollment":                       {"enrollment", "require_unauthenticated"},
+		"default-user-settings-flow":                      {"stage_configuration", "require_authenticated"},
+	} {
+		flows[slug] = s.Add("/flows/instances/", Object{
+			"name":           slug,
+			"slug":           slug,
+			"title":          slug,
+			"designation":    flow[0],
+			"authentication": flow[1],
+		})
+	}
+	s.Add("/stages/identification/", Object{
+		"name":        "default-authentication-identification",
+		"component":   "ak-stage-identification-form",
+		"user_fields": []string{"username", "email"},
+	})
+	s.Add("/sources/all/", Object{
+		"name":    "authentik Built-in",
+		"slug":    "authentik-built-in",
+		"managed": "goauthentik.io/sources/inbuilt",
+	})
+
+	cert, key := selfSignedCertificate()
+	kp := s.Add("/crypto/certificatekeypairs/", Object{
+		"name":             "authentik Self-signed Certificate",
+		"certificate_data": cert,
+		"key_data":         key,
+	})
+	s.Add("/core/tenants/", Object{
+		"domain":              "authentik-default",
+		"default":             true,
+		"branding_title":      "authentik",
+		"flow_authentication": flows["default-authentication-flow"]["pk"],
+		"flow_invalidation":   flows["default-invalidation-flow"]["pk"],
+		"flow_user_settings":  flows["default-user-settings-flow"]["pk"],
+		"web_certificate":     kp["pk"],
+		"attributes":          map[string]interface{}{},
+	})
+
+	for managed, name := range map[string]string{
+		"goauthentik.io/providers/oauth2/scope-openid":         "authentik default OAuth Mapping: OpenID 'openid'",
+		"goauthentik.io/providers/oauth2/scope-email":          "authentik default OAuth Mapping: OpenID 'email'",
+		"goauthentik.io/providers/oauth2/scope-profile":        "authentik default OAuth Mapping: OpenID 'profile'",
+		"goauthentik.io/providers/oauth2/scope-offline_access": "authentik default OAuth Mapping: OpenID 'offline_access'",
+		"goauthentik.io/providers/proxy/scope-proxy":           "authentik default OAuth Mapping: Proxy outpost",
+	} {
+		scope := strings.TrimPrefix(managed[strings.LastIndex(managed, "/")+1:], "scope-")
+		s.Add("/propertymappings/scope/", Object{"name": name, "managed": managed, "scope_name": scope})
+	}
+	for managed, mapping := range map[string][2]string{
+		"goauthentik.io/providers/saml/upn":                   {"authentik default SAML Mapping: UPN", "http://schemas.xmlsoap.org/ws/2005/05/identity/claims/upn"},
+		"goauthentik.io/providers/saml/name":                  {"authentik default SAML Mapping: Name", "http://schemas.xmlsoap.org/ws/2005/05/identity/claims/name"},
+		"goauthentik.io/providers/saml/email":                 {"authentik default SAML Mapping: Email", "http://schemas.xmlsoap.org/ws/2005/05/identity/claims/emailaddress"},
+		"goauthentik.io/providers/saml/username":              {"authentik default SAML Mapping: Username", "http://schemas.goauthentik.io/2021/02/saml/username"},
+		"goauthentik.io/providers/saml/uid":                   {"authentik default SAML Mapping: User ID", "http://schemas.goauthentik.io/2021/02/saml/uid"},
+		"goauthentik.io/providers/saml/groups":                {"authentik default SAML Mapping: Groups", "http://schemas.xmlsoap.org/claims/Group"},
+		"goauthentik.io/providers/saml/ms-windowsaccountname": {"authentik default SAML Mapping: WindowsAccountname (Username)", "http://schemas.microsoft.com/ws/2008/06/identity/claims/windowsaccountname"},
+	} {
+		s.Add("/propertymappings/saml/", Object{"name": mapping[0], "managed": managed, "saml_name": mapping[1]})
+	}
+	for managed, mapping := range map[string][2]string{
+		"goauthentik.io/sources/ldap/default-name":         {"authentik default LDAP Mapping: Name", "name"},
+		"goauthentik.io/sources/ldap/default-mail":         {"authentik default LDAP Mapping: mail", "email"},
+		"goauthentik.io/sources/ldap/ms-userprincipalname": {"authentik default Active Directory Mapping: userPrincipalName", "attributes.upn"},
+		"goauthentik.io/sources/ldap/ms-givenName":         {"authentik default Active Directory Mapping: givenName", "attributes.givenName"},
+		"goauthentik.io/sources/ldap/ms-sn":                {"authentik default Active Directory Mapping: sn", "attributes.sn"},
+		"goauthentik.io/sources/ldap/openldap-uid":         {"authentik default OpenLDAP Mapping: uid", "attributes.uid"},
+		"goauthentik.io/sources/ldap/openldap-cn":          {"authentik default OpenLDAP Mapping: cn", "attributes.cn"},
+	} {
+		s.Add("/propertymappings/ldap/", Object{"name": mapping[0], "managed": managed, "object_field": mapping[1]})
+	}
+	for managed, name := range map[string]string{
+		"goauthentik.io/providers/scim/user":  "authentik default SCIM Mapping: User",
+		"goauthentik.io/providers/scim/group": "authentik default SCIM Mapping: Group",
+	} {
+		s.Add("/propertymappings/scim/", Object{"name": name, "managed": managed})
+	}
+}
+
+// selfSignedCertificate Generate a PEM encoded certificate and private key
+func selfSignedCertificate() (string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		panic(err)
+	}
+	// The subject is built in the same order as authentik does
+	subject, err := asn1.Marshal(pkix.RDNSequence{
+		{{Type: asn1.ObjectIdentifier{2, 5, 4, 3}, Value: "authentik Self-signed Certificate"}},
+		{{Type: asn1.ObjectIdentifier{2, 5, 4, 10}, Value: "authentik"}},
+		{{Type: asn1.ObjectIdentifier{2, 5, 4, 11}, Value: "Self-signed"}},
+	})
+	if err != nil {
+		panic(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		RawSubject:   subject,
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(365 * 24 * time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		panic(err)
+	}
+	keyDer, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		panic(err)
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})),
+		string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDer}))
+}
+
+// actionSetURL Action which stores the URL sent in the request in field
+func actionSetURL(field string) ActionFunc {
+	return func(s *Server, obj Object, r *http.Request) (int, interface{}) {
+		if r.Method != http.MethodPost {
+			return http.StatusMethodNotAllowed, map[string]string{"detail": fmt.Sprintf("Method \"%s\" not allowed.", r.Method)}
+		}
+		body, err := decodeBody(r)
+		if err != nil {
+			return http.StatusBadRequest, map[string]string{"detail": err.Error()}
+		}
+		obj[field] = body["url"]
+		return http.StatusOK, nil
+	}
+}
+
+// actionViewField Action which returns the value of field as `data`, without trailing newlines like authentik
+func actionViewField(field string) ActionFunc {
+	return func(s *Server, obj Object, r *http.Request) (int, interface{}) {
+		return http.StatusOK, map[string]interface{}{"data": strings.TrimRight(fmt.Sprint(obj[field]), "\n")}
+	}
+}
+
+// computeUser Users are superusers when one of their groups is
+func computeUser(s *Server, obj Object) {
+	obj["is_superuser"] = false
+	groups, _ := obj["groups"].([]interface{})
+	for _, pk := range groups {
+		if _, group := s.collections["/core/groups/"].find(fmt.Sprint(pk)); group != nil && group["is_superuser"] == true {
+			obj["is_superuser"] = true
+		}
+	}
+}
+
+// computeCertificateKeyPair Set the details of the certificate
+func computeCertificateKeyPair(s *Server, obj Object) {
+	obj["private_key_available"] = fmt.Sprint(obj["key_data"]) != ""
+	block, _ := pem.Decode([]byte(fmt.Sprint(obj["certificate_data"])))
+	if block == nil {
+		return
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return
+	}
+	obj["cert_expiry"] = cert.NotAfter.UTC().Format(time.RFC3339)
+	// Formatted from the raw subject, to keep the order of its attributes
+	var subject pkix.RDNSequence
+	if _, err := asn1.Unmarshal(cert.RawSubject, &subject); err == nil {
+		obj["cert_subject"] = subject.String()
+	}
+	sha1Sum := sha1.Sum(cert.Raw)
+	sha256Sum := sha256.Sum256(cert.Raw)
+	obj["fingerprint_sha1"] = fingerprint(sha1Sum[:])
+	obj["fingerprint_sha256"] = fingerprint(sha256Sum[:])
+}
+
+func fingerprint(sum []byte) string {
+	parts := []string{}
+	for _, b := range sum {
+		parts = append(parts, hex.EncodeToString([]byte{b}))
+	}
+	return strings.Join(parts, ":")
+}
+
+func actionViewKey(s *Server, obj Object, r *http.Request) (int, interface{}) {
+	if _, ok := obj["key"]; !ok {
+		obj["key"] = fmt.Sprintf("key-%s", obj["identifier"])
+	}
+	return http.StatusOK, map[string]interface{}{"key": obj["key"]}
+}
+
+func actionSetPassword(s *Server, obj Object, r *http.Request) (int, interface{}) {
+	body, err := decodeBody(r)
+	if err != nil {
+		return http.StatusBadRequest, map[string]string{"detail": err.Error()}
+	}
+	if fmt.Sprint(body["password"]) == "" {
+		return http.StatusBadRequest, map[string][]string{"password": {"This field may not be blank."}}
+	}
+	return http.StatusNoContent, nil
+}
+
+func actionOAuth2SetupURLs(s *Server, obj Object, r *http.Request) (int, interface{}) {
+	base := "http://authentik.invalid/application/o"
+	return http.StatusOK, map[string]interface{}{
+		"issuer":        fmt.Sprintf("%s/%v/", base, obj["name"]),
+		"authorize":     base + "/authorize/",
+		"token":         base + "/token/",
+		"user_info":     base + "/userinfo/",
+		"provider_info": fmt.Sprintf("%s/%v/.well-known/openid-configuration", base, obj["name"]),
+		"logout":        fmt.Sprintf("%s/%v/end-session/", base, obj["name"]),
+		"jwks":          fmt.Sprintf("%s/%v/jwks/", base, obj["name"]),
+	}
+}
+
+func actionSAMLMetadata(s *Server, obj Object, r *http.Request) (int, interface{}) {
+	return http.StatusOK, map[string]interface{}{
+		"metadata":     fmt.Sprintf(`<md:EntityDescriptor xmlns:md="urn:oasis:names:tc:SAML:2.0:metadata" entityID="%v"/>`, obj["name"]),
+		"download_url": "",
+	}
+}
@@ -0,0 +1,464 @@
+// Package fakeauthentik implements an in-memory stand-in for the parts of the authentik API used by
+// the provider, so that provider tests can run without a live authentik instance or network access.
+//
+// Objects are stored as plain JSON objects, so the fake doesn't validate the shape of requests
+// beyond the rules configured for each collection. Create, retrieve, update, partial update, delete
+// and paginated, filtered lists are supported on every collection.
+package fakeauthentik
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+// BasePath Path prefix of all API endpoints
+const BasePath = "/api/v3"
+
+// DefaultPageSize Page size used when a list request doesn't specify one, same as authentik
+const DefaultPageSize = 100
+
+// Object A single object stored in the fake API
+type Object map[string]interface{}
+
+// ActionFunc Handler for a custom action on an object, like `/core/users/{id}/set_password/`.
+// The returned body is encoded as JSON, unless it is nil.
+type ActionFunc func(s *Server, obj Object, r *http.Request) (int, interface{})
+
+// Collection Configuration of an API collection like `/core/groups/`
+type Collection struct {
+	// Lookup Field used to address objects in URLs, defaults to PK
+	Lookup string
+	// PK Field the primary key is stored in, defaults to `pk`
+	PK string
+	// IntPK Primary keys are incrementing integers instead of UUIDs
+	IntPK bool
+	// Unique Fields which must be unique within the collection
+	Unique []string
+	// Actions Custom actions on objects, by their name
+	Actions map[string]ActionFunc
+	// Computed Sets the fields authentik computes itself, like the fingerprints of a certificate.
+	// It's called after every create and update.
+	Computed func(s *Server, obj Object)
+	// Includes Other collections whose objects are also listed in this collection,
+	// like `/stages/all/` which lists the objects of all stage collections
+	Includes []string
+
+	path    string
+	objects []Object
+}
+
+func (c *Collection) lookup() string {
+	if c.Lookup != "" {
+		return c.Lookup
+	}
+	return c.pk()
+}
+
+func (c *Collection) pk() string {
+	if c.PK != "" {
+		return c.PK
+	}
+	return "pk"
+}
+
+func (c *Collection) find(id string) (int, Object) {
+	for idx, obj := range c.objects {
+		if fmt.Sprint(obj[c.lookup()]) == id {
+			return idx, obj
+		}
+	}
+	return -1, nil
+}
+
+// Server In-memory authentik API
+type Server struct {
+	// Version Version of authentik reported by `/admin/version/`
+	Version string
+	// PageSize Page size used when a list request doesn't specify one
+	PageSize int
+
+	mu          sync.Mutex
+	collections map[string]*Collection
+	singletons  map[string]func(s *Server, r *http.Request) (int, interface{})
+	nextID      int32
+}
+
+// NewServer Create an empty fake API without any collections
+func NewServer() *Server {
+	return &Server{
+		Version:     "2023.8.3",
+		PageSize:    DefaultPageSize,
+		collections: map[string]*Collection{},
+		singletons:  map[string]func(s *Server, r *http.Request) (int, interface{}){},
+		nextID:      1,
+	}
+}
+
+// Register Add a collection at path, like `/core/groups/`
+func (s *Server) Register(path string, c Collection) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	c.path = path
+	s.collections[path] = &c
+}
+
+// RegisterSingleton Add an endpoint at path which isn't backed by a collection, like `/admin/version/`
+func (s *Server) RegisterSingleton(path string, handler func(s *Server, r *http.Request) (int, interface{})) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.singletons[path] = handler
+}
+
+// Add Store obj in the collection at path, as if it had been created through the API.
+// The stored object, including its generated primary key, is returned.
+func (s *Server) Add(path string, obj Object) Object {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	c, ok := s.collections[path]
+	if !ok {
+		panic(fmt.Sprintf("fakeauthentik: unknown collection %s", path))
+	}
+	return s.create(c, obj)
+}
+
+// Get Retrieve an object by the value of its lookup field
+func (s *Server) Get(path string, id string) (Object, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	c, ok := s.collections[path]
+	if !ok {
+		return nil, false
+	}
+	_, obj := c.find(id)
+	return obj, obj != nil
+}
+
+// Remove Delete an object by the value of its lookup field, as if it had been deleted outside of Terraform
+func (s *Server) Remove(path string, id string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	c, ok := s.collections[path]
+	if !ok {
+		return false
+	}
+	idx, _ := c.find(id)
+	if idx < 0 {
+		return false
+	}
+	c.objects = append(c.objects[:idx], c.objects[idx+1:]...)
+	return true
+}
+
+// Transport Get a HTTP transport which serves all requests from this fake in-process,
+// without opening any network connections
+func (s *Server) Transport() http.RoundTripper {
+	return &transport{s}
+}
+
+type transport struct {
+	server *Server
+}
+
+func (t *transport) RoundTrip(r *http.Request) (*http.Response, error) {
+	rec := httptest.NewRecorder()
+	t.server.ServeHTTP(rec, r)
+	res := rec.Result()
+	res.Request = r
+	return res, nil
+}
+
+func (s *Server) create(c *Collection, obj Object) Object {
+	stored := Object{}
+	for k, v := range obj {
+		stored[k] = v
+	}
+	if c.IntPK {
+		stored[c.pk()] = s.nextID
+		s.nextID += 1
+	} else {
+		stored[c.pk()] = uuid.New().String()
+	}
+	if _, ok := stored["managed"]; !ok {
+		stored["managed"] = nil
+	}
+	if c.Computed != nil {
+		c.Computed(s, stored)
+	}
+	c.objects = append(c.objects, stored)
+	return stored
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	if body == nil {
+		w.WriteHeader(status)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func notFound(w http.ResponseWriter) {
+	writeJSON(w, http.StatusNotFound, map[string]string{"detail": "Not found."})
+}
+
+func methodNotAllowed(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusMethodNotAllowed, map[string]string{"detail": fmt.Sprintf("Method \"%s\" not allowed.", r.Method)})
+}
+
+func decodeBody(r *http.Request) (Object, error) {
+	obj := Object{}
+	if r.Body == nil || r.ContentLength == 0 {
+		return obj, nil
+	}
+	dec := json.NewDecoder(r.Body)
+	dec.UseNumber()
+	err := dec.Decode(&obj)
+	return obj, err
+}
+
+// ServeHTTP Handle a request to the fake API
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	path := strings.TrimPrefix(r.URL.Path, BasePath)
+	if !strings.HasSuffix(path, "/") {
+		path += "/"
+	}
+	if handler, ok := s.singletons[path]; ok {
+		status, body := handler(s, r)
+		writeJSON(w, status, body)
+		return
+	}
+	if c, ok := s.collections[path]; ok {
+		switch r.Method {
+		case http.MethodGet:
+			s.list(w, r, c)
+		case http.MethodPost:
+			obj, err := decodeBody(r)
+			if err != nil {
+				writeJSON(w, http.StatusBadRequest, map[string]string{"detail": err.Error()})
+				return
+			}
+			if errs := s.validate(c, obj, nil); len(errs) > 0 {
+				writeJSON(w, http.StatusBadRequest, errs)
+				return
+			}
+			writeJSON(w, http.StatusCreated, s.create(c, obj))
+		default:
+			methodNotAllowed(w, r)
+		}
+		return
+	}
+
+	// Find the collection the object belongs to, and an optional action
+	c, id, action := s.resolve(path)
+	if c == nil {
+		notFound(w)
+		return
+	}
+	idx, obj := c.find(id)
+	if obj == nil {
+		notFound(w)
+		return
+	}
+	if action != "" {
+		fn, ok := c.Actions[action]
+		if !ok {
+			notFound(w)
+			return
+		}
+		status, body := fn(s, obj, r)
+		writeJSON(w, status, body)
+		return
+	}
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, obj)
+	case http.MethodPut, http.MethodPatch:
+		update, err := decodeBody(r)
+		if err != nil {
+			writeJSON(w, http.StatusBadRequest, map[string]string{"detail": err.Error()})
+			return
+		}
+		if errs := s.validate(c, update, obj); len(errs) > 0 {
+			writeJSON(w, http.StatusBadRequest, errs)
+			return
+		}
+		for k, v := range update {
+			// The primary key is read-only
+			if k == c.pk() {
+				continue
+			}
+			obj[k] = v
+		}
+		if c.Computed != nil {
+			c.Computed(s, obj)
+		}
+		writeJSON(w, http.StatusOK, obj)
+	case http.MethodDelete:
+		c.objects = append(c.objects[:idx], c.objects[idx+1:]...)
+		writeJSON(w, http.StatusNoContent, nil)
+	default:
+		methodNotAllowed(w, r)
+	}
+}
+
+// resolve Split a path like `/core/users/4/set_password/` into its collection, object ID and action
+func (s *Server) resolve(path string) (*Collection, string, string) {
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	for _, suffix := range []int{1, 2} {
+		if len(parts) <= suffix {
+			continue
+		}
+		collection := "/" + strings.Join(parts[:len(parts)-suffix], "/") + "/"
+		if c, ok := s.collections[collection]; ok {
+			action := ""
+			if suffix == 2 {
+				action = parts[len(parts)-1]
+			}
+			return c, parts[len(parts)-suffix], action
+		}
+	}
+	return nil, "", ""
+}
+
+// slugPattern Characters allowed in slugs
+var slugPattern = regexp.MustCompile(`^[-a-zA-Z0-9_]*$`)
+
+// validate Check an object before it's created, or before existing is updated
+func (s *Server) validate(c *Collection, obj Object, existing Object) map[string][]string {
+	errs := map[string][]string{}
+	if v, ok := obj["slug"]; ok && !slugPattern.MatchString(fmt.Sprint(v)) {
+		errs["slug"] = []string{"Enter a valid “slug” consisting of letters, numbers, underscores or hyphens."}
+	}
+	for _, field := range c.Unique {
+		v, ok := obj[field]
+		if !ok {
+			continue
+		}
+		// Fields that identify an object can't be blank, others like the name of a user can
+		if fmt.Sprint(v) == "" {
+			errs[field] = []string{"This field may not be blank."}
+			continue
+		}
+		for _, other := range c.objects {
+			if existing != nil && other[c.pk()] == existing[c.pk()] {
+				continue
+			}
+			if fmt.Sprint(other[field]) == fmt.Sprint(v) {
+				errs[field] = []string{fmt.Sprintf("object with this %s already exists.", field)}
+			}
+		}
+	}
+	return errs
+}
+
+// listParams Query parameters which control the list itself and aren't filters
+var listParams = map[string]bool{
+	"page":      true,
+	"page_size": true,
+	"ordering":  true,
+	"search":    true,
+}
+
+// matches Check if obj matches the filters in the query. Filters on fields the fake doesn't know
+// are ignored, filters with multiple values match if any of the values match.
+func matches(obj Object, query map[string][]string) bool {
+	for key, values := range query {
+		if listParams[key] {
+			continue
+		}
+		field := strings.TrimSuffix(key, "_startswith")
+		v, ok := obj[field]
+		if !ok {
+			continue
+		}
+		actual := fmt.Sprint(v)
+		if v == nil {
+			actual = ""
+		}
+		found := false
+		for _, value := range values {
+			if field != key && strings.HasPrefix(actual, value) || actual == value {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *Server) list(w http.ResponseWriter, r *http.Request, c *Collection) {
+	all := append([]Object{}, c.objects...)
+	includes := append([]string{}, c.Includes...)
+	sort.Strings(includes)
+	for _, include := range includes {
+		if ic, ok := s.collections[include]; ok {
+			all = append(all, ic.objects...)
+		}
+	}
+	query := r.URL.Query()
+	results := []Object{}
+	for _, obj := range all {
+		if matches(obj, query) {
+			results = append(results, obj)
+		}
+	}
+
+	page, err := strconv.Atoi(query.Get("page"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+	pageSize, err := strconv.Atoi(query.Get("page_size"))
+	if err != nil || pageSize < 1 {
+		pageSize = s.PageSize
+	}
+	totalPages := int(math.Ceil(float64(len(results)) / float64(pageSize)))
+	if page > totalPages && page > 1 {
+		writeJSON(w, http.StatusNotFound, map[string]string{"detail": "Invalid page."})
+		return
+	}
+	start := (page - 1) * pageSize
+	end := start + pageSize
+	if end > len(results) {
+		end = len(results)
+	}
+	next, previous := 0, 0
+	if page < totalPages {
+		next = page + 1
+	}
+	if page > 1 {
+		previous = page - 1
+	}
+	startIndex := 0
+	if end > start {
+		startIndex = start + 1
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"pagination": map[string]interface{}{
+			"next":        next,
+			"previous":    previous,
+			"count":       len(results),
+			"current":     page,
+			"total_pages": totalPages,
+			"start_index": startIndex,
+			"end_index":   end,
+		},
+		"results": results[start:end],
+	})
+}
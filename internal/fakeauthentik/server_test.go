@@ -0,0 +1,217 @@
+package fakeauthentik
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	api "goauthentik.io/api/v3"
+)
+
+func testClient(s *Server) *api.APIClient {
+	config := api.NewConfiguration()
+	config.Host = "authentik.invalid"
+	config.Scheme = "http"
+	config.HTTPClient = &http.Client{Transport: s.Transport()}
+	return api.NewAPIClient(config)
+}
+
+func TestServer_CRUD(t *testing.T) {
+	s := New()
+	c := testClient(s)
+	ctx := context.Background()
+
+	group, _, err := c.CoreApi.CoreGroupsCreate(ctx).GroupRequest(api.GroupRequest{
+		Name:       "test",
+		Attributes: map[string]interface{}{"foo": "bar"},
+	}).Execute()
+	assert.NoError(t, err)
+	assert.NotEmpty(t, group.Pk)
+
+	group, _, err = c.CoreApi.CoreGroupsRetrieve(ctx, group.Pk).Execute()
+	assert.NoError(t, err)
+	assert.Equal(t, "test", group.Name)
+	assert.Equal(t, "bar", group.Attributes["foo"])
+
+	name := "renamed"
+	group, _, err = c.CoreApi.CoreGroupsPartialUpdate(ctx, group.Pk).PatchedGroupRequest(api.PatchedGroupRequest{
+		Name: &name,
+	}).Execute()
+	assert.NoError(t, err)
+	assert.Equal(t, "renamed", group.Name)
+	assert.Equal(t, "bar", group.Attributes["foo"])
+
+	_, err = c.CoreApi.CoreGroupsDestroy(ctx, group.Pk).Execute()
+	assert.NoError(t, err)
+	_, hr, err := c.CoreApi.CoreGroupsRetrieve(ctx, group.Pk).Execute()
+	assert.Error(t, err)
+	assert.Equal(t, http.StatusNotFound, hr.StatusCode)
+	hr, err = c.CoreApi.CoreGroupsDestroy(ctx, group.Pk).Execute()
+	assert.Error(t, err)
+	assert.Equal(t, http.StatusNotFound, hr.StatusCode)
+}
+
+func TestServer_Lookup(t *testing.T) {
+	s := New()
+	c := testClient(s)
+	ctx := context.Background()
+
+	app, _, err := c.CoreApi.CoreApplicationsCreate(ctx).ApplicationRequest(api.ApplicationRequest{
+		Name: "test",
+		Slug: "test-app",
+	}).Execute()
+	assert.NoError(t, err)
+	app, _, err = c.CoreApi.CoreApplicationsRetrieve(ctx, "test-app").Execute()
+	assert.NoError(t, err)
+	assert.Equal(t, "test", app.Name)
+
+	hr, err := c.CoreApi.CoreApplicationsSetIconUrlCreate(ctx, "test-app").FilePathRequest(api.FilePathRequest{
+		Url: "https://goauthentik.io/img/icon.png",
+	}).Execute()
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, hr.StatusCode)
+	app, _, err = c.CoreApi.CoreApplicationsRetrieve(ctx, "test-app").Execute()
+	assert.NoError(t, err)
+	assert.Equal(t, "https://goauthentik.io/img/icon.png", app.GetMetaIcon())
+
+	user, _, err := c.CoreApi.CoreUsersCreate(ctx).UserRequest(api.UserRequest{
+		Username: "test",
+		Name:     "test",
+	}).Execute()
+	assert.NoError(t, err)
+	user, _, err = c.CoreApi.CoreUsersRetrieve(ctx, user.Pk).Execute()
+	assert.NoError(t, err)
+	assert.Equal(t, "test", user.Username)
+}
+
+func TestServer_Validation(t *testing.T) {
+	s := New()
+	c := testClient(s)
+	ctx := context.Background()
+
+	_, hr, err := c.CoreApi.CoreGroupsCreate(ctx).GroupRequest(api.GroupRequest{Name: ""}).Execute()
+	assert.Error(t, err)
+	assert.Equal(t, http.StatusBadRequest, hr.StatusCode)
+	assert.Contains(t, string(err.(*api.GenericOpenAPIError).Body()), "This field may not be blank.")
+
+	_, hr, err = c.CoreApi.CoreGroupsCreate(ctx).GroupRequest(api.GroupRequest{Name: "authentik Admins"}).Execute()
+	assert.Error(t, err)
+	assert.Equal(t, http.StatusBadRequest, hr.StatusCode)
+	assert.Contains(t, string(err.(*api.GenericOpenAPIError).Body()), "already exists")
+
+	_, hr, err = c.FlowsApi.FlowsInstancesCreate(ctx).FlowRequest(api.FlowRequest{Name: "foo", Slug: "foo+", Title: "foo", Designation: api.FLOWDESIGNATIONENUM_AUTHENTICATION}).Execute()
+	assert.Error(t, err)
+	assert.Equal(t, http.StatusBadRequest, hr.StatusCode)
+	assert.Contains(t, string(err.(*api.GenericOpenAPIError).Body()), "consisting of letters, numbers, underscores or hyphens")
+
+	// Users don't need a name
+	_, hr, err = c.CoreApi.CoreUsersCreate(ctx).UserRequest(api.UserRequest{Username: "foo"}).Execute()
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusCreated, hr.StatusCode)
+}
+
+func TestServer_Computed(t *testing.T) {
+	s := New()
+	c := testClient(s)
+	ctx := context.Background()
+
+	kps, _, err := c.CryptoApi.CryptoCertificatekeypairsList(ctx).Name("authentik Self-signed Certificate").Execute()
+	assert.NoError(t, err)
+	assert.Len(t, kps.Results, 1)
+	assert.Equal(t, "OU=Self-signed,O=authentik,CN=authentik Self-signed Certificate", *kps.Results[0].CertSubject.Get())
+	assert.NotNil(t, kps.Results[0].CertExpiry.Get())
+	assert.Len(t, *kps.Results[0].FingerprintSha256.Get(), 95)
+	assert.True(t, kps.Results[0].PrivateKeyAvailable)
+
+	// Users are superusers through their groups
+	users, _, err := c.CoreApi.CoreUsersList(ctx).Username("akadmin").Execute()
+	assert.NoError(t, err)
+	assert.True(t, users.Results[0].IsSuperuser)
+	user, _, err := c.CoreApi.CoreUsersCreate(ctx).UserRequest(api.UserRequest{Username: "foo"}).Execute()
+	assert.NoError(t, err)
+	assert.False(t, user.IsSuperuser)
+}
+
+func TestServer_Pagination(t *testing.T) {
+	s := New()
+	s.PageSize = 2
+	c := testClient(s)
+	ctx := context.Background()
+
+	for i := 0; i < 5; i++ {
+		_, _, err := c.CoreApi.CoreGroupsCreate(ctx).GroupRequest(api.GroupRequest{
+			Name: fmt.Sprintf("group-%d", i),
+		}).Execute()
+		assert.NoError(t, err)
+	}
+
+	names := []string{}
+	page := int32(1)
+	for {
+		res, _, err := c.CoreApi.CoreGroupsList(ctx).Page(page).Execute()
+		assert.NoError(t, err)
+		assert.Equal(t, float32(6), res.Pagination.Count)
+		assert.Equal(t, float32(3), res.Pagination.TotalPages)
+		for _, g := range res.Results {
+			names = append(names, g.Name)
+		}
+		if res.Pagination.Next == 0 {
+			break
+		}
+		page = int32(res.Pagination.Next)
+	}
+	assert.Equal(t, []string{"authentik Admins", "group-0", "group-1", "group-2", "group-3", "group-4"}, names)
+
+	_, hr, err := c.CoreApi.CoreGroupsList(ctx).Page(4).Execute()
+	assert.Error(t, err)
+	assert.Equal(t, http.StatusNotFound, hr.StatusCode)
+}
+
+func TestServer_Filter(t *testing.T) {
+	s := New()
+	c := testClient(s)
+	ctx := context.Background()
+
+	res, _, err := c.CoreApi.CoreUsersList(ctx).Username("akadmin").Execute()
+	assert.NoError(t, err)
+	assert.Len(t, res.Results, 1)
+	res, _, err = c.CoreApi.CoreUsersList(ctx).Username("foo").Execute()
+	assert.NoError(t, err)
+	assert.Len(t, res.Results, 0)
+
+	mappings, _, err := c.PropertymappingsApi.PropertymappingsSamlList(ctx).Managed([]string{
+		"goauthentik.io/providers/saml/upn",
+		"goauthentik.io/providers/saml/name",
+	}).Execute()
+	assert.NoError(t, err)
+	assert.Len(t, mappings.Results, 2)
+
+	sources, _, err := c.SourcesApi.SourcesAllList(ctx).Managed("goauthentik.io/sources/inbuilt").Execute()
+	assert.NoError(t, err)
+	assert.Len(t, sources.Results, 1)
+	assert.Equal(t, "authentik-built-in", sources.Results[0].Slug)
+}
+
+func TestServer_Remove(t *testing.T) {
+	s := New()
+	c := testClient(s)
+	ctx := context.Background()
+
+	flow, ok := s.Get("/flows/instances/", "default-authentication-flow")
+	assert.True(t, ok)
+	assert.Equal(t, "authentication", flow["designation"])
+	assert.True(t, s.Remove("/flows/instances/", "default-authentication-flow"))
+	_, hr, err := c.FlowsApi.FlowsInstancesRetrieve(ctx, "default-authentication-flow").Execute()
+	assert.Error(t, err)
+	assert.Equal(t, http.StatusNotFound, hr.StatusCode)
+}
+
+func TestServer_Version(t *testing.T) {
+	s := New()
+	s.Version = "2023.6.1"
+	v, _, err := testClient(s).AdminApi.AdminVersionRetrieve(context.Background()).Execute()
+	assert.NoError(t, err)
+	assert.Equal(t, "2023.6.1", v.VersionCurrent)
+}
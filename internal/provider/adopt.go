@@ -0,0 +1,155 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// adoptableResources Natural keys of the resources which can adopt existing objects. Existing
+// objects are found with the `<key>:<value>` import ID of the resource.
+var adoptableResources = map[string]string{
+	"authentik_application":                   "slug",
+	"authentik_blueprint":                     "name",
+	"authentik_certificate_key_pair":          "name",
+	"authentik_event_rule":                    "name",
+	"authentik_event_transport":               "name",
+	"authentik_flow":                          "slug",
+	"authentik_group":                         "name",
+	"authentik_outpost":                       "name",
+	"authentik_policy_dummy":                  "name",
+	"authentik_policy_event_matcher":          "name",
+	"authentik_policy_expiry":                 "name",
+	"authentik_policy_expression":             "name",
+	"authentik_policy_password":               "name",
+	"authentik_policy_reputation":             "name",
+	"authentik_property_mapping_ldap":         "name",
+	"authentik_property_mapping_notification": "name",
+	"authentik_property_mapping_saml":         "name",
+	"authentik_property_mapping_scim":         "name",
+	"authentik_provider_ldap":                 "name",
+	"authentik_provider_oauth2":               "name",
+	"authentik_provider_proxy":                "name",
+	"authentik_provider_radius":               "name",
+	"authentik_provider_saml":                 "name",
+	"authentik_provider_scim":                 "name",
+	"authentik_scope_mapping":                 "name",
+	"authentik_service_connection_docker":     "name",
+	"authentik_service_connection_kubernetes": "name",
+	"authentik_source_ldap":                   "slug",
+	"authentik_source_oauth":                  "slug",
+	"authentik_source_plex":                   "slug",
+	"authentik_source_saml":                   "slug",
+	"authentik_stage_authenticator_duo":       "name",
+	"authentik_stage_authenticator_sms":       "name",
+	"authentik_stage_authenticator_static":    "name",
+	"authentik_stage_authenticator_totp":      "name",
+	"authentik_stage_authenticator_validate":  "name",
+	"authentik_stage_authenticator_webauthn":  "name",
+	"authentik_stage_captcha":                 "name",
+	"authentik_stage_consent":                 "name",
+	"authentik_stage_deny":                    "name",
+	"authentik_stage_dummy":                   "name",
+	"authentik_stage_email":                   "name",
+	"authentik_stage_identification":          "name",
+	"authentik_stage_invitation":              "name",
+	"authentik_stage_password":                "name",
+	"authentik_stage_prompt":                  "name",
+	"authentik_stage_prompt_field":            "name",
+	"authentik_stage_user_delete":             "name",
+	"authentik_stage_user_login":              "name",
+	"authentik_stage_user_logout":             "name",
+	"authentik_stage_user_write":              "name",
+	"authentik_tenant":                        "domain",
+	"authentik_user":                          "username",
+}
+
+// withAdoption Add the `adopt_existing` attribute to all adoptable resources, and adopt existing
+// objects when creating an object fails because its natural key is already taken
+func withAdoption(resources map[string]*schema.Resource) map[string]*schema.Resource {
+	for name, key := range adoptableResources {
+		r := resources[name]
+		r.Schema["adopt_existing"] = &schema.Schema{
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Description: fmt.Sprintf("When an object with the same `%s` already exists, update it and take it into the state instead of failing. Overrides the provider's `adopt_existing` setting.", key),
+		}
+		r.CreateContext = adoptingCreate(r, key, r.CreateContext)
+	}
+	return resources
+}
+
+// adoptExistingEnabled Check if existing objects should be adopted, override is the resource's
+// `adopt_existing` attribute
+func adoptExistingEnabled(override cty.Value, c *APIClient) bool {
+	if !override.IsNull() && override.IsKnown() {
+		return override.True()
+	}
+	return c.adoptExisting
+}
+
+// isConflict Check if creating an object failed with a validation error of its natural key, which is
+// the case when the key is already taken. Only 400 responses are turned into attribute-scoped
+// diagnostics by httpToDiag, so the message itself isn't checked. Whether an object with the key
+// actually exists is confirmed by looking it up.
+func isConflict(diags diag.Diagnostics, key string) bool {
+	for _, d := range diags {
+		if d.Severity == diag.Error && d.AttributePath.Equals(cty.GetAttrPath(key)) {
+			return true
+		}
+	}
+	return false
+}
+
+func adoptingCreate(r *schema.Resource, key string, create schema.CreateContextFunc) schema.CreateContextFunc {
+	return func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		diags := create(ctx, d, m)
+		if !diags.HasError() || !isConflict(diags, key) {
+			return diags
+		}
+		override := cty.NullVal(cty.Bool)
+		if config := d.GetRawConfig(); !config.IsNull() && config.IsKnown() {
+			override = config.GetAttr("adopt_existing")
+		}
+		if !adoptExistingEnabled(override, m.(*APIClient)) {
+			return diags
+		}
+
+		value := d.Get(key).(string)
+		lookup := r.Data(nil)
+		lookup.SetId(fmt.Sprintf("%s:%s", key, value))
+		found, err := r.Importer.StateContext(ctx, lookup, m)
+		if err != nil {
+			// The validation error wasn't caused by an existing object
+			tflog.Debug(ctx, "authentik: no existing object to adopt", map[string]interface{}{
+				"key":   key,
+				"value": value,
+				"error": err.Error(),
+			})
+			return diags
+		}
+		d.SetId(found[0].Id())
+		tflog.Warn(ctx, "authentik: adopting existing object", map[string]interface{}{
+			"key":   key,
+			"value": value,
+			"id":    d.Id(),
+		})
+		updateDiags := r.UpdateContext(ctx, d, m)
+		if updateDiags.HasError() {
+			// Nothing is taken into the state, otherwise the existing object would be tainted and destroyed
+			d.SetId("")
+			return updateDiags
+		}
+		return append(diag.Diagnostics{
+			{
+				Severity: diag.Warning,
+				Summary:  "Existing object was adopted",
+				Detail:   fmt.Sprintf("An object with %s %q already existed (ID %s). It was updated to the configured values and taken into the state instead of being created.", key, value, d.Id()),
+			},
+		}, updateDiags...)
+	}
+}
@@ -0,0 +1,111 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"goauthentik.io/terraform-provider-authentik/internal/fakeauthentik"
+)
+
+func Test_adoptExisting(t *testing.T) {
+	testServer.Add("/flows/instances/", fakeauthentik.Object{"name": "adopt", "slug": "adopt-flow", "title": "old", "designation": "authentication"})
+	group := testServer.Add("/core/groups/", fakeauthentik.Object{"name": "adopt-group", "is_superuser": true})
+	defer func() {
+		testServer.Remove("/core/groups/", group["pk"].(string))
+		testServer.Remove("/flows/instances/", "adopt-flow")
+	}()
+
+	p := testProviderWithConfig(t, map[string]interface{}{"adopt_existing": true})
+	r := p.ResourcesMap["authentik_flow"]
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"name":        "adopt",
+		"slug":        "adopt-flow",
+		"title":       "new",
+		"designation": "authentication",
+	})
+	diags := r.CreateContext(context.Background(), d, p.Meta())
+	assert.False(t, diags.HasError(), diags)
+	assert.Equal(t, diag.Warning, diags[0].Severity)
+	assert.Equal(t, "Existing object was adopted", diags[0].Summary)
+	assert.Equal(t, "adopt-flow", d.Id())
+	flow, _ := testServer.Get("/flows/instances/", "adopt-flow")
+	assert.Equal(t, "new", flow["title"])
+
+	r = p.ResourcesMap["authentik_group"]
+	d = schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{"name": "adopt-group"})
+	diags = r.CreateContext(context.Background(), d, p.Meta())
+	assert.False(t, diags.HasError(), diags)
+	assert.Equal(t, group["pk"], d.Id())
+	obj, _ := testServer.Get("/core/groups/", group["pk"].(string))
+	assert.Equal(t, false, obj["is_superuser"])
+
+	// Without adopting, conflicts fail
+	p = testProviderWithConfig(t, map[string]interface{}{})
+	r = p.ResourcesMap["authentik_group"]
+	d = schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{"name": "adopt-group"})
+	diags = r.CreateContext(context.Background(), d, p.Meta())
+	assert.True(t, diags.HasError())
+	assert.Equal(t, "object with this name already exists.", diags[0].Summary)
+	assert.Equal(t, "", d.Id())
+}
+
+func Test_adoptExistingEnabled(t *testing.T) {
+	assert.True(t, adoptExistingEnabled(cty.NullVal(cty.Bool), &APIClient{adoptExisting: true}))
+	assert.False(t, adoptExistingEnabled(cty.NullVal(cty.Bool), &APIClient{}))
+	assert.True(t, adoptExistingEnabled(cty.True, &APIClient{}))
+	assert.False(t, adoptExistingEnabled(cty.False, &APIClient{adoptExisting: true}))
+}
+
+func Test_isConflict(t *testing.T) {
+	conflict := diag.Diagnostics{{
+		Severity:      diag.Error,
+		Summary:       "flow with this slug already exists.",
+		AttributePath: cty.GetAttrPath("slug"),
+	}}
+	assert.True(t, isConflict(conflict, "slug"))
+	assert.False(t, isConflict(conflict, "name"))
+	// Errors which aren't attribute-scoped validation errors aren't conflicts
+	assert.False(t, isConflict(diag.Diagnostics{{
+		Severity: diag.Error,
+		Summary:  "HTTP Error '500 Internal Server Error' during request 'POST /api/v3/flows/instances/'",
+	}}, "slug"))
+	assert.False(t, isConflict(diag.Diagnostics{{
+		Severity:      diag.Warning,
+		Summary:       "flow with this slug already exists.",
+		AttributePath: cty.GetAttrPath("slug"),
+	}}, "slug"))
+}
+
+func Test_adoptExisting_UpdateFails(t *testing.T) {
+	group := testServer.Add("/core/groups/", fakeauthentik.Object{
+		"name":       "adopt-foreign-group",
+		"attributes": map[string]interface{}{ownershipAttribute: "workspace-b"},
+	})
+	defer testServer.Remove("/core/groups/", group["pk"].(string))
+
+	p := testProviderWithConfig(t, map[string]interface{}{"adopt_existing": true, "ownership_key": "workspace-a"})
+	r := p.ResourcesMap["authentik_group"]
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{"name": "adopt-foreign-group"})
+	diags := r.CreateContext(context.Background(), d, p.Meta())
+	assert.True(t, diags.HasError())
+	assert.Equal(t, "Object is owned by another workspace", diags[0].Summary)
+	// The existing object isn't taken into the state, so it's never destroyed
+	assert.Equal(t, "", d.Id())
+	_, ok := testServer.Get("/core/groups/", group["pk"].(string))
+	assert.True(t, ok)
+}
+
+func Test_withAdoption(t *testing.T) {
+	p := Provider("test", false)
+	for name := range adoptableResources {
+		r, ok := p.ResourcesMap[name]
+		assert.True(t, ok, name)
+		assert.Contains(t, r.Schema, "adopt_existing", name)
+		assert.Contains(t, r.Schema, adoptableResources[name], name)
+		assert.NotNil(t, r.Importer, name)
+	}
+}
@@ -0,0 +1,126 @@
+package provider
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// decodeAttributes Decode a JSON attributes string, an empty string is treated as an empty object
+func decodeAttributes(raw string) (map[string]interface{}, error) {
+	attr := make(map[string]interface{})
+	if raw == "" {
+		return attr, nil
+	}
+	err := json.NewDecoder(strings.NewReader(raw)).Decode(&attr)
+	if err != nil {
+		return nil, err
+	}
+	return attr, nil
+}
+
+// attributesSchemaToModel Decode the `attributes` of a resource and merge the provider's
+// `default_attributes` into them. Keys set on the resource take precedence over default attributes.
+// When the provider has an `ownership_key`, the object is stamped with it.
+func attributesSchemaToModel(d *schema.ResourceData, c *APIClient) (map[string]interface{}, diag.Diagnostics) {
+	attr, err := decodeAttributes(d.Get("attributes").(string))
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	for k, v := range c.defaultAttributes {
+		if _, ok := attr[k]; !ok {
+			attr[k] = v
+		}
+	}
+	if c.ownershipKey != "" {
+		attr[ownershipAttribute] = c.ownershipKey
+	}
+	return attr, nil
+}
+
+// attributesModelToSchema Encode the attributes of an object for the state. Default attributes are
+// left out unless they're set on the resource or have been changed outside of Terraform, so that
+// diffSuppressJSON only compares the keys set in the configuration. In merge mode, keys which
+// aren't managed by the resource are left out as well, and so is the ownership stamp.
+func attributesModelToSchema(d *schema.ResourceData, c *APIClient, attr map[string]interface{}) (string, diag.Diagnostics) {
+	configured, err := decodeAttributes(d.Get("attributes").(string))
+	if err != nil {
+		configured = map[string]interface{}{}
+	}
+	merge := d.Get("attributes_mode") == attributesModeMerge
+	owned := ownedAttributeKeys(d, c, configured)
+	state := make(map[string]interface{}, len(attr))
+	for k, v := range attr {
+		if _, ok := owned[k]; merge && !ok {
+			continue
+		}
+		if _, set := configured[k]; k == ownershipAttribute && !set {
+			continue
+		}
+		if dv, ok := c.defaultAttributes[k]; ok && reflect.DeepEqual(dv, v) {
+			if _, set := configured[k]; !set {
+				continue
+			}
+		}
+		state[k] = v
+	}
+	b, err := json.Marshal(state)
+	if err != nil {
+		return "", diag.FromErr(err)
+	}
+	return string(b), nil
+}
+
+const (
+	// attributesModeAuthoritative The attributes of the object are replaced with the configured attributes
+	attributesModeAuthoritative = "authoritative"
+	// attributesModeMerge Only the configured and managed keys are read and written, other keys are preserved
+	attributesModeMerge = "merge"
+)
+
+// ownedAttributeKeys Keys of the attributes that are managed by this resource in merge mode
+func ownedAttributeKeys(d *schema.ResourceData, c *APIClient, configured map[string]interface{}) map[string]struct{} {
+	owned := make(map[string]struct{})
+	for k := range configured {
+		owned[k] = struct{}{}
+	}
+	for k := range c.defaultAttributes {
+		owned[k] = struct{}{}
+	}
+	if keys, ok := d.Get("managed_attribute_keys").(*schema.Set); ok {
+		for _, k := range keys.List() {
+			owned[k.(string)] = struct{}{}
+		}
+	}
+	return owned
+}
+
+// attributesMerge Merge the attributes of a resource in merge mode into the attributes currently set on the
+// object. Keys which were managed before but have been removed from the configuration are removed, both
+// keys of the previous `attributes` and of the previous `managed_attribute_keys`.
+func attributesMerge(d *schema.ResourceData, c *APIClient, attr map[string]interface{}, current map[string]interface{}) map[string]interface{} {
+	old, _ := d.GetChange("attributes")
+	previous, err := decodeAttributes(old.(string))
+	if err != nil {
+		previous = map[string]interface{}{}
+	}
+	merged := make(map[string]interface{}, len(current))
+	for k, v := range current {
+		merged[k] = v
+	}
+	for k := range ownedAttributeKeys(d, c, previous) {
+		delete(merged, k)
+	}
+	if oldKeys, _ := d.GetChange("managed_attribute_keys"); oldKeys != nil {
+		for _, k := range oldKeys.(*schema.Set).List() {
+			delete(merged, k.(string))
+		}
+	}
+	for k, v := range attr {
+		merged[k] = v
+	}
+	return merged
+}
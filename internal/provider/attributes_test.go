@@ -0,0 +1,224 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+)
+
+func testProviderWithConfig(t *testing.T, config map[string]interface{}) *schema.Provider {
+	p, err := providerTestFactories["authentik"]()
+	assert.NoError(t, err)
+	diags := p.Configure(context.Background(), terraform.NewResourceConfigRaw(config))
+	assert.False(t, diags.HasError())
+	return p
+}
+
+func Test_attributesSchemaToModel(t *testing.T) {
+	c := &APIClient{defaultAttributes: map[string]interface{}{
+		"managed-by":  "terraform",
+		"cost-center": "1234",
+	}}
+	d := resourceGroup().TestResourceData()
+	assert.NoError(t, d.Set("attributes", `{"cost-center": "5678", "foo": {"bar": true}}`))
+	attr, diags := attributesSchemaToModel(d, c)
+	assert.Nil(t, diags)
+	assert.Equal(t, map[string]interface{}{
+		"managed-by":  "terraform",
+		"cost-center": "5678",
+		"foo":         map[string]interface{}{"bar": true},
+	}, attr)
+
+	assert.NoError(t, d.Set("attributes", `{`))
+	_, diags = attributesSchemaToModel(d, c)
+	assert.True(t, diags.HasError())
+}
+
+func Test_attributesModelToSchema(t *testing.T) {
+	c := &APIClient{defaultAttributes: map[string]interface{}{
+		"managed-by":  "terraform",
+		"cost-center": "1234",
+	}}
+	d := resourceGroup().TestResourceData()
+	assert.NoError(t, d.Set("attributes", `{"cost-center": "1234"}`))
+	attr, diags := attributesModelToSchema(d, c, map[string]interface{}{
+		"managed-by":  "terraform",
+		"cost-center": "1234",
+		"foo":         "bar",
+	})
+	assert.Nil(t, diags)
+	// Default attributes are kept when they're set on the resource
+	assert.Equal(t, `{"cost-center":"1234","foo":"bar"}`, attr)
+
+	// Default attributes changed outside of Terraform show up as drift
+	attr, diags = attributesModelToSchema(d, c, map[string]interface{}{
+		"managed-by":  "someone",
+		"cost-center": "1234",
+	})
+	assert.Nil(t, diags)
+	assert.Equal(t, `{"cost-center":"1234","managed-by":"someone"}`, attr)
+}
+
+func Test_defaultAttributes(t *testing.T) {
+	p := testProviderWithConfig(t, map[string]interface{}{
+		"default_attributes": map[string]interface{}{
+			"managed-by":  "terraform",
+			"cost-center": "1234",
+		},
+	})
+	for name, r := range map[string]*schema.Resource{
+		"/core/users/":   p.ResourcesMap["authentik_user"],
+		"/core/groups/":  p.ResourcesMap["authentik_group"],
+		"/core/tenants/": p.ResourcesMap["authentik_tenant"],
+	} {
+		t.Run(name, func(t *testing.T) {
+			d := r.TestResourceData()
+			for k, v := range map[string]string{
+				"name":       "default-attributes",
+				"username":   "default-attributes",
+				"domain":     "default-attributes.invalid",
+				"type":       "internal",
+				"attributes": `{"cost-center": "5678", "foo": "bar"}`,
+			} {
+				if _, ok := r.Schema[k]; ok {
+					assert.NoError(t, d.Set(k, v))
+				}
+			}
+			diags := r.CreateContext(context.Background(), d, p.Meta())
+			assert.False(t, diags.HasError(), diags)
+			defer func() {
+				testServer.Remove(name, d.Id())
+			}()
+
+			obj, ok := testServer.Get(name, d.Id())
+			assert.True(t, ok)
+			assert.Equal(t, map[string]interface{}{
+				"managed-by":  "terraform",
+				"cost-center": "5678",
+				"foo":         "bar",
+			}, obj["attributes"])
+			// Default attributes aren't stored in the state, so they don't cause a diff
+			assert.JSONEq(t, `{"cost-center": "5678", "foo": "bar"}`, d.Get("attributes").(string))
+		})
+	}
+}
+
+func Test_attributesMode_Merge(t *testing.T) {
+	p := testProviderWithConfig(t, map[string]interface{}{})
+	for name, r := range map[string]*schema.Resource{
+		"/core/users/":  p.ResourcesMap["authentik_user"],
+		"/core/groups/": p.ResourcesMap["authentik_group"],
+	} {
+		t.Run(name, func(t *testing.T) {
+			d := r.TestResourceData()
+			for k, v := range map[string]interface{}{
+				"name":                   "attributes-merge",
+				"username":               "attributes-merge",
+				"type":                   "internal",
+				"attributes":             `{"foo": "bar", "removed": true}`,
+				"attributes_mode":        attributesModeMerge,
+				"managed_attribute_keys": []interface{}{"managed"},
+			} {
+				if _, ok := r.Schema[k]; ok {
+					assert.NoError(t, d.Set(k, v))
+				}
+			}
+			diags := r.CreateContext(context.Background(), d, p.Meta())
+			assert.False(t, diags.HasError(), diags)
+			defer func() {
+				testServer.Remove(name, d.Id())
+			}()
+
+			// Keys set outside of Terraform aren't shown as drift, unless they're managed
+			obj, _ := testServer.Get(name, d.Id())
+			obj["attributes"].(map[string]interface{})["goauthentik.io/user/sources"] = []interface{}{"ldap"}
+			obj["attributes"].(map[string]interface{})["managed"] = "foo"
+			diags = r.ReadContext(context.Background(), d, p.Meta())
+			assert.False(t, diags.HasError(), diags)
+			assert.JSONEq(t, `{"foo": "bar", "removed": true, "managed": "foo"}`, d.Get("attributes").(string))
+
+			// Updates preserve keys set outside of Terraform, and remove managed keys which aren't configured
+			state := d.State()
+			state.Attributes["attributes"] = `{"foo": "bar", "removed": true}`
+			d = r.Data(state)
+			assert.NoError(t, d.Set("attributes", `{"foo": "baz"}`))
+			diags = r.UpdateContext(context.Background(), d, p.Meta())
+			assert.False(t, diags.HasError(), diags)
+			obj, _ = testServer.Get(name, d.Id())
+			assert.Equal(t, map[string]interface{}{
+				"foo":                         "baz",
+				"goauthentik.io/user/sources": []interface{}{"ldap"},
+			}, obj["attributes"])
+			assert.JSONEq(t, `{"foo": "baz"}`, d.Get("attributes").(string))
+
+			// Keys which are no longer managed are removed
+			obj["attributes"].(map[string]interface{})["managed"] = "foo"
+			d = r.Data(d.State())
+			assert.NoError(t, d.Set("managed_attribute_keys", []interface{}{}))
+			diags = r.UpdateContext(context.Background(), d, p.Meta())
+			assert.False(t, diags.HasError(), diags)
+			obj, _ = testServer.Get(name, d.Id())
+			assert.Equal(t, map[string]interface{}{
+				"foo":                         "baz",
+				"goauthentik.io/user/sources": []interface{}{"ldap"},
+			}, obj["attributes"])
+		})
+	}
+}
+
+func Test_attributesMode_Authoritative(t *testing.T) {
+	p := testProviderWithConfig(t, map[string]interface{}{})
+	r := p.ResourcesMap["authentik_group"]
+	d := r.TestResourceData()
+	assert.NoError(t, d.Set("name", "attributes-authoritative"))
+	assert.NoError(t, d.Set("attributes", `{"foo": "bar"}`))
+	diags := r.CreateContext(context.Background(), d, p.Meta())
+	assert.False(t, diags.HasError(), diags)
+	defer func() {
+		testServer.Remove("/core/groups/", d.Id())
+	}()
+
+	obj, _ := testServer.Get("/core/groups/", d.Id())
+	obj["attributes"].(map[string]interface{})["goauthentik.io/user/sources"] = []interface{}{"ldap"}
+	diags = r.ReadContext(context.Background(), d, p.Meta())
+	assert.False(t, diags.HasError(), diags)
+	assert.JSONEq(t, `{"foo": "bar", "goauthentik.io/user/sources": ["ldap"]}`, d.Get("attributes").(string))
+}
+
+func Test_attributesMode_Upgrade(t *testing.T) {
+	p := testProviderWithConfig(t, map[string]interface{}{})
+	for name, r := range map[string]*schema.Resource{
+		"/core/users/":  p.ResourcesMap["authentik_user"],
+		"/core/groups/": p.ResourcesMap["authentik_group"],
+	} {
+		t.Run(name, func(t *testing.T) {
+			d := r.TestResourceData()
+			for k, v := range map[string]interface{}{
+				"name":     "attributes-upgrade",
+				"username": "attributes-upgrade",
+				"type":     "internal",
+			} {
+				if _, ok := r.Schema[k]; ok {
+					assert.NoError(t, d.Set(k, v))
+				}
+			}
+			diags := r.CreateContext(context.Background(), d, p.Meta())
+			assert.False(t, diags.HasError(), diags)
+			defer func() {
+				testServer.Remove(name, d.Id())
+			}()
+
+			// State written by a version without `attributes_mode`
+			state := d.State()
+			delete(state.Attributes, "attributes_mode")
+			d = r.Data(state)
+			assert.Equal(t, "", d.Get("attributes_mode"))
+			diags = r.ReadContext(context.Background(), d, p.Meta())
+			assert.False(t, diags.HasError(), diags)
+			assert.Equal(t, attributesModeAuthoritative, d.Get("attributes_mode"))
+		})
+	}
+}
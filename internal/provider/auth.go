@@ -0,0 +1,218 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// tokenExpiryLeeway Tokens are refreshed this long before they actually expire
+const tokenExpiryLeeway = 30 * time.Second
+
+// tokenSource Source of the Bearer token used to authenticate against authentik
+type tokenSource interface {
+	Token(ctx context.Context) (string, error)
+}
+
+type staticTokenSource string
+
+func (ts staticTokenSource) Token(ctx context.Context) (string, error) {
+	return string(ts), nil
+}
+
+// cachedTokenSource Token source that caches a token until it expires
+type cachedTokenSource struct {
+	fetch func(ctx context.Context) (string, time.Time, error)
+
+	mu     sync.Mutex
+	token  string
+	expiry time.Time
+}
+
+func (ts *cachedTokenSource) Token(ctx context.Context) (string, error) {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	if ts.token != "" && (ts.expiry.IsZero() || time.Now().Add(tokenExpiryLeeway).Before(ts.expiry)) {
+		return ts.token, nil
+	}
+	token, expiry, err := ts.fetch(ctx)
+	if err != nil {
+		return "", err
+	}
+	if token == "" {
+		return "", errors.New("received empty token")
+	}
+	ts.token = token
+	ts.expiry = expiry
+	return token, nil
+}
+
+// providerTokenSource Get the token source for the authentication method configured in the provider
+func providerTokenSource(d *schema.ResourceData, akURL *url.URL, client *http.Client) (tokenSource, error) {
+	token := d.Get("token").(string)
+	tokenFile := d.Get("token_file").(string)
+	execBlock := d.Get("exec").([]interface{})
+	ccBlock := d.Get("client_credentials").([]interface{})
+
+	configured := 0
+	for _, set := range []bool{token != "", tokenFile != "", len(execBlock) > 0, len(ccBlock) > 0} {
+		if set {
+			configured += 1
+		}
+	}
+	if configured == 0 {
+		return nil, errors.New("one of `token`, `token_file`, `exec` or `client_credentials` must be configured")
+	}
+	if configured > 1 {
+		return nil, errors.New("only one of `token`, `token_file`, `exec` or `client_credentials` can be configured")
+	}
+
+	switch {
+	case tokenFile != "":
+		raw, err := os.ReadFile(tokenFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read token file: %w", err)
+		}
+		token = strings.TrimSpace(string(raw))
+		if token == "" {
+			return nil, fmt.Errorf("token file %s is empty", tokenFile)
+		}
+	case len(execBlock) > 0:
+		e := execBlock[0].(map[string]interface{})
+		env := map[string]string{}
+		for k, v := range e["env"].(map[string]interface{}) {
+			env[k] = v.(string)
+		}
+		return &cachedTokenSource{
+			fetch: execTokenFetcher(e["command"].(string), castSlice[string](e["args"].([]interface{})), env),
+		}, nil
+	case len(ccBlock) > 0:
+		cc := ccBlock[0].(map[string]interface{})
+		return &cachedTokenSource{
+			fetch: clientCredentialsTokenFetcher(
+				client,
+				akURL.ResolveReference(&url.URL{Path: "/application/o/token/"}).String(),
+				cc["client_id"].(string),
+				cc["username"].(string),
+				cc["password"].(string),
+				cc["scope"].(string),
+			),
+		}, nil
+	}
+	return staticTokenSource(token), nil
+}
+
+// execCredential JSON document a credential helper has to write to stdout
+type execCredential struct {
+	Token      string `json:"token"`
+	Expiration string `json:"expiration"`
+}
+
+func execTokenFetcher(command string, args []string, env map[string]string) func(ctx context.Context) (string, time.Time, error) {
+	return func(ctx context.Context) (string, time.Time, error) {
+		cmd := exec.CommandContext(ctx, command, args...)
+		cmd.Env = os.Environ()
+		for k, v := range env {
+			cmd.Env = append(cmd.Env, fmt.Sprintf("%s=%s", k, v))
+		}
+		stderr := &bytes.Buffer{}
+		cmd.Stderr = stderr
+		out, err := cmd.Output()
+		if err != nil {
+			return "", time.Time{}, fmt.Errorf("credential helper %s failed: %w: %s", command, err, strings.TrimSpace(stderr.String()))
+		}
+		var cred execCredential
+		err = json.Unmarshal(out, &cred)
+		if err != nil {
+			return "", time.Time{}, fmt.Errorf("failed to parse output of credential helper %s: %w", command, err)
+		}
+		var expiry time.Time
+		if cred.Expiration != "" {
+			expiry, err = time.Parse(time.RFC3339, cred.Expiration)
+			if err != nil {
+				return "", time.Time{}, fmt.Errorf("failed to parse expiration of credential helper %s: %w", command, err)
+			}
+		}
+		return cred.Token, expiry, nil
+	}
+}
+
+// clientCredentialsResponse Token response of authentik's OAuth2 token endpoint
+type clientCredentialsResponse struct {
+	AccessToken      string `json:"access_token"`
+	ExpiresIn        int    `json:"expires_in"`
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+func clientCredentialsTokenFetcher(client *http.Client, tokenURL string, clientID string, username string, password string, scope string) func(ctx context.Context) (string, time.Time, error) {
+	return func(ctx context.Context) (string, time.Time, error) {
+		form := url.Values{
+			"grant_type": []string{"client_credentials"},
+			"client_id":  []string{clientID},
+			"username":   []string{username},
+			"password":   []string{password},
+			"scope":      []string{scope},
+		}
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, tokenURL, strings.NewReader(form.Encode()))
+		if err != nil {
+			return "", time.Time{}, err
+		}
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		req.Header.Set("Accept", "application/json")
+		res, err := client.Do(req)
+		if err != nil {
+			return "", time.Time{}, fmt.Errorf("failed to request token: %w", err)
+		}
+		defer res.Body.Close()
+		var token clientCredentialsResponse
+		err = json.NewDecoder(res.Body).Decode(&token)
+		if err != nil {
+			return "", time.Time{}, fmt.Errorf("failed to parse token response (HTTP %d): %w", res.StatusCode, err)
+		}
+		if res.StatusCode != http.StatusOK {
+			return "", time.Time{}, fmt.Errorf("failed to request token (HTTP %d): %s %s", res.StatusCode, token.Error, token.ErrorDescription)
+		}
+		var expiry time.Time
+		if token.ExpiresIn > 0 {
+			expiry = time.Now().Add(time.Duration(token.ExpiresIn) * time.Second)
+		}
+		return token.AccessToken, expiry, nil
+	}
+}
+
+// authTransport Transport that adds the current token of a tokenSource to every request
+type authTransport struct {
+	inner  http.RoundTripper
+	source tokenSource
+}
+
+// NewAuthTransport Get a HTTP Transport that authenticates requests with a Bearer token
+func NewAuthTransport(inner http.RoundTripper, source tokenSource) *authTransport {
+	return &authTransport{inner, source}
+}
+
+// RoundTrip HTTP Transport
+func (at *authTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	token, err := at.source.Token(r.Context())
+	if err != nil {
+		if r.Body != nil {
+			_ = r.Body.Close()
+		}
+		return nil, fmt.Errorf("failed to get authentik token: %w", err)
+	}
+	req := r.Clone(r.Context())
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	return at.inner.RoundTrip(req)
+}
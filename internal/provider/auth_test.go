@@ -0,0 +1,169 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testTokenSource(t *testing.T, raw map[string]interface{}, akURL string) (tokenSource, error) {
+	u, err := url.Parse(akURL)
+	assert.NoError(t, err)
+	return providerTokenSource(testTLSResourceData(t, raw), u, http.DefaultClient)
+}
+
+func Test_providerTokenSource_Static(t *testing.T) {
+	ts, err := testTokenSource(t, map[string]interface{}{"token": "foo"}, "http://localhost")
+	assert.NoError(t, err)
+	token, err := ts.Token(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "foo", token)
+}
+
+func Test_providerTokenSource_Invalid(t *testing.T) {
+	_, err := testTokenSource(t, map[string]interface{}{}, "http://localhost")
+	assert.Error(t, err)
+
+	_, err = testTokenSource(t, map[string]interface{}{
+		"token":      "foo",
+		"token_file": "/token",
+	}, "http://localhost")
+	assert.Error(t, err)
+}
+
+func Test_providerTokenSource_File(t *testing.T) {
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	assert.NoError(t, os.WriteFile(tokenFile, []byte("foo\n"), 0o600))
+
+	ts, err := testTokenSource(t, map[string]interface{}{"token_file": tokenFile}, "http://localhost")
+	assert.NoError(t, err)
+	token, err := ts.Token(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "foo", token)
+}
+
+func Test_providerTokenSource_Exec(t *testing.T) {
+	ts, err := testTokenSource(t, map[string]interface{}{
+		"exec": []interface{}{
+			map[string]interface{}{
+				"command": "sh",
+				"args":    []interface{}{"-c", `echo "{\"token\": \"$TOKEN\"}"`},
+				"env": map[string]interface{}{
+					"TOKEN": "foo",
+				},
+			},
+		},
+	}, "http://localhost")
+	assert.NoError(t, err)
+	token, err := ts.Token(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "foo", token)
+
+	ts, err = testTokenSource(t, map[string]interface{}{
+		"exec": []interface{}{
+			map[string]interface{}{
+				"command": "sh",
+				"args":    []interface{}{"-c", "exit 1"},
+			},
+		},
+	}, "http://localhost")
+	assert.NoError(t, err)
+	_, err = ts.Token(context.Background())
+	assert.Error(t, err)
+}
+
+func Test_providerTokenSource_ClientCredentials(t *testing.T) {
+	calls := int32(0)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/application/o/token/", r.URL.Path)
+		assert.NoError(t, r.ParseForm())
+		if r.PostForm.Get("password") != "app-password" {
+			w.WriteHeader(http.StatusBadRequest)
+			_ = json.NewEncoder(w).Encode(map[string]string{"error": "invalid_grant"})
+			return
+		}
+		assert.Equal(t, "client_credentials", r.PostForm.Get("grant_type"))
+		assert.Equal(t, "client", r.PostForm.Get("client_id"))
+		assert.Equal(t, "svc", r.PostForm.Get("username"))
+		assert.Equal(t, "goauthentik.io/api", r.PostForm.Get("scope"))
+		n := atomic.AddInt32(&calls, 1)
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"access_token": fmt.Sprintf("jwt-%d", n),
+			"token_type":   "bearer",
+			// Expires within the leeway, so every call triggers a refresh
+			"expires_in": 1,
+		})
+	}))
+	defer srv.Close()
+
+	ts, err := testTokenSource(t, map[string]interface{}{
+		"client_credentials": []interface{}{
+			map[string]interface{}{
+				"client_id": "client",
+				"username":  "svc",
+				"password":  "app-password",
+			},
+		},
+	}, srv.URL)
+	assert.NoError(t, err)
+	token, err := ts.Token(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "jwt-1", token)
+	token, err = ts.Token(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "jwt-2", token)
+
+	ts, err = testTokenSource(t, map[string]interface{}{
+		"client_credentials": []interface{}{
+			map[string]interface{}{
+				"client_id": "client",
+				"username":  "svc",
+				"password":  "wrong",
+			},
+		},
+	}, srv.URL)
+	assert.NoError(t, err)
+	_, err = ts.Token(context.Background())
+	assert.ErrorContains(t, err, "invalid_grant")
+}
+
+func Test_cachedTokenSource(t *testing.T) {
+	calls := 0
+	ts := &cachedTokenSource{
+		fetch: func(ctx context.Context) (string, time.Time, error) {
+			calls += 1
+			return "foo", time.Now().Add(time.Hour), nil
+		},
+	}
+	for i := 0; i < 3; i++ {
+		token, err := ts.Token(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, "foo", token)
+	}
+	assert.Equal(t, 1, calls)
+}
+
+func Test_authTransport(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer foo", r.Header.Get("Authorization"))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	client := &http.Client{
+		Transport: NewAuthTransport(http.DefaultTransport, staticTokenSource("foo")),
+	}
+	res, err := client.Get(srv.URL)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNoContent, res.StatusCode)
+}
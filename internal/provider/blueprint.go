@@ -0,0 +1,479 @@
+package provider
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/zclconf/go-cty/cty"
+	"gopkg.in/yaml.v3"
+)
+
+// blueprintModels Resource types of the blueprint models which can be converted
+var blueprintModels = map[string]string{
+	"authentik_blueprints.blueprintinstance":                             "authentik_blueprint",
+	"authentik_core.application":                                         "authentik_application",
+	"authentik_core.group":                                               "authentik_group",
+	"authentik_core.token":                                               "authentik_token",
+	"authentik_core.user":                                                "authentik_user",
+	"authentik_crypto.certificatekeypair":                                "authentik_certificate_key_pair",
+	"authentik_events.notificationrule":                                  "authentik_event_rule",
+	"authentik_events.notificationtransport":                             "authentik_event_transport",
+	"authentik_events.notificationwebhookmapping":                        "authentik_property_mapping_notification",
+	"authentik_flows.flow":                                               "authentik_flow",
+	"authentik_flows.flowstagebinding":                                   "authentik_flow_stage_binding",
+	"authentik_outposts.dockerserviceconnection":                         "authentik_service_connection_docker",
+	"authentik_outposts.kubernetesserviceconnection":                     "authentik_service_connection_kubernetes",
+	"authentik_outposts.outpost":                                         "authentik_outpost",
+	"authentik_policies.policybinding":                                   "authentik_policy_binding",
+	"authentik_policies_dummy.dummypolicy":                               "authentik_policy_dummy",
+	"authentik_policies_event_matcher.eventmatcherpolicy":                "authentik_policy_event_matcher",
+	"authentik_policies_expiry.passwordexpirypolicy":                     "authentik_policy_expiry",
+	"authentik_policies_expression.expressionpolicy":                     "authentik_policy_expression",
+	"authentik_policies_password.passwordpolicy":                         "authentik_policy_password",
+	"authentik_policies_reputation.reputationpolicy":                     "authentik_policy_reputation",
+	"authentik_providers_ldap.ldapprovider":                              "authentik_provider_ldap",
+	"authentik_providers_oauth2.oauth2provider":                          "authentik_provider_oauth2",
+	"authentik_providers_oauth2.scopemapping":                            "authentik_scope_mapping",
+	"authentik_providers_proxy.proxyprovider":                            "authentik_provider_proxy",
+	"authentik_providers_radius.radiusprovider":                          "authentik_provider_radius",
+	"authentik_providers_saml.samlpropertymapping":                       "authentik_property_mapping_saml",
+	"authentik_providers_saml.samlprovider":                              "authentik_provider_saml",
+	"authentik_providers_scim.scimmapping":                               "authentik_property_mapping_scim",
+	"authentik_providers_scim.scimprovider":                              "authentik_provider_scim",
+	"authentik_sources_ldap.ldappropertymapping":                         "authentik_property_mapping_ldap",
+	"authentik_sources_ldap.ldapsource":                                  "authentik_source_ldap",
+	"authentik_sources_oauth.oauthsource":                                "authentik_source_oauth",
+	"authentik_sources_plex.plexsource":                                  "authentik_source_plex",
+	"authentik_sources_saml.samlsource":                                  "authentik_source_saml",
+	"authentik_stages_authenticator_duo.authenticatorduostage":           "authentik_stage_authenticator_duo",
+	"authentik_stages_authenticator_sms.authenticatorsmsstage":           "authentik_stage_authenticator_sms",
+	"authentik_stages_authenticator_static.authenticatorstaticstage":     "authentik_stage_authenticator_static",
+	"authentik_stages_authenticator_totp.authenticatortotpstage":         "authentik_stage_authenticator_totp",
+	"authentik_stages_authenticator_validate.authenticatorvalidatestage": "authentik_stage_authenticator_validate",
+	"authentik_stages_authenticator_webauthn.authenticatewebauthnstage":  "authentik_stage_authenticator_webauthn",
+	"authentik_stages_captcha.captchastage":                              "authentik_stage_captcha",
+	"authentik_stages_consent.consentstage":                              "authentik_stage_consent",
+	"authentik_stages_deny.denystage":                                    "authentik_stage_deny",
+	"authentik_stages_dummy.dummystage":                                  "authentik_stage_dummy",
+	"authentik_stages_email.emailstage":                                  "authentik_stage_email",
+	"authentik_stages_identification.identificationstage":                "authentik_stage_identification",
+	"authentik_stages_invitation.invitationstage":                        "authentik_stage_invitation",
+	"authentik_stages_password.passwordstage":                            "authentik_stage_password",
+	"authentik_stages_prompt.prompt":                                     "authentik_stage_prompt_field",
+	"authentik_stages_prompt.promptstage":                                "authentik_stage_prompt",
+	"authentik_stages_user_delete.userdeletestage":                       "authentik_stage_user_delete",
+	"authentik_stages_user_login.userloginstage":                         "authentik_stage_user_login",
+	"authentik_stages_user_logout.userlogoutstage":                       "authentik_stage_user_logout",
+	"authentik_stages_user_write.userwritestage":                         "authentik_stage_user_write",
+	"authentik_tenants.tenant":                                           "authentik_tenant",
+}
+
+// blueprintFindDataSources Data sources `!Find` lookups of a model are converted to
+var blueprintFindDataSources = map[string]string{
+	"authentik_core.group":                         "authentik_group",
+	"authentik_core.user":                          "authentik_user",
+	"authentik_crypto.certificatekeypair":          "authentik_certificate_key_pair",
+	"authentik_flows.flow":                         "authentik_flow",
+	"authentik_providers_oauth2.scopemapping":      "authentik_scope_mapping",
+	"authentik_providers_saml.samlpropertymapping": "authentik_property_mapping_saml",
+	"authentik_providers_scim.scimmapping":         "authentik_property_mapping_scim",
+	"authentik_sources_ldap.ldappropertymapping":   "authentik_property_mapping_ldap",
+	"authentik_tenants.tenant":                     "authentik_tenant",
+}
+
+// blueprintConverter State of a blueprint conversion
+type blueprintConverter struct {
+	p   *schema.Provider
+	log io.Writer
+	// entry Description of the entry being converted, for warnings
+	entry string
+
+	// context Default values of the blueprint's context
+	context map[string]yaml.Node
+	// keys References to the resources of entries with an `id`, for `!KeyOf`
+	keys map[string]string
+	// labels Resource and data source addresses which are taken
+	labels map[string]bool
+	// finds References to the data sources of `!Find` lookups, by the lookup
+	finds map[string]string
+
+	variables   *hclwrite.File
+	dataSources *hclwrite.File
+	resources   *hclwrite.File
+}
+
+// blueprintEntry Single entry of a blueprint
+type blueprintEntry struct {
+	Model       string               `yaml:"model"`
+	ID          string               `yaml:"id"`
+	State       string               `yaml:"state"`
+	Identifiers map[string]yaml.Node `yaml:"identifiers"`
+	Attrs       map[string]yaml.Node `yaml:"attrs"`
+	Conditions  []yaml.Node          `yaml:"conditions"`
+
+	resource string
+	label    string
+}
+
+// ConvertBlueprint Convert an authentik blueprint into Terraform configuration using this provider's
+// resources. Entries and values which can't be converted are skipped, with a warning written to log.
+func ConvertBlueprint(in []byte, out io.Writer, log io.Writer) error {
+	if log == nil {
+		log = io.Discard
+	}
+	var bp struct {
+		Metadata struct {
+			Name string `yaml:"name"`
+		} `yaml:"metadata"`
+		Context map[string]yaml.Node `yaml:"context"`
+		Entries []*blueprintEntry    `yaml:"entries"`
+	}
+	err := yaml.Unmarshal(in, &bp)
+	if err != nil {
+		return fmt.Errorf("failed to parse blueprint: %w", err)
+	}
+	c := &blueprintConverter{
+		p:           Provider("", false),
+		log:         log,
+		context:     bp.Context,
+		keys:        map[string]string{},
+		labels:      map[string]bool{},
+		finds:       map[string]string{},
+		variables:   hclwrite.NewEmptyFile(),
+		dataSources: hclwrite.NewEmptyFile(),
+		resources:   hclwrite.NewEmptyFile(),
+	}
+
+	// Assign the resource names first, so that `!KeyOf` can reference later entries
+	entries := []*blueprintEntry{}
+	for idx, e := range bp.Entries {
+		c.entry = fmt.Sprintf("entry %d (%s)", idx+1, e.Model)
+		resource, ok := blueprintModels[e.Model]
+		if !ok {
+			c.warn("model %q isn't supported, the entry was skipped", e.Model)
+			continue
+		}
+		if e.State == "absent" {
+			c.warn("entries with `state: absent` can't be converted, the entry was skipped")
+			continue
+		}
+		e.resource = resource
+		e.label = c.label("resource", resource, c.entryLabel(e))
+		if e.ID != "" {
+			ref := "id"
+			if _, ok := c.p.ResourcesMap[resource].Schema["uuid"]; ok {
+				// `!KeyOf` returns the primary key, which isn't the ID for resources imported by slug
+				ref = "uuid"
+			}
+			c.keys[e.ID] = fmt.Sprintf("%s.%s.%s", resource, e.label, ref)
+		}
+		entries = append(entries, e)
+	}
+
+	for idx, e := range entries {
+		c.entry = fmt.Sprintf("%s %q", e.resource, e.label)
+		if idx > 0 {
+			c.resources.Body().AppendNewline()
+		}
+		c.convertEntry(e)
+	}
+
+	if bp.Metadata.Name != "" {
+		_, err = fmt.Fprintf(out, "# Converted from the authentik blueprint %q\n\n", bp.Metadata.Name)
+		if err != nil {
+			return err
+		}
+	}
+	sections := [][]byte{}
+	for _, f := range []*hclwrite.File{c.variables, c.dataSources, c.resources} {
+		if len(f.Body().Blocks()) > 0 {
+			sections = append(sections, hclwrite.Format(f.Bytes()))
+		}
+	}
+	_, err = out.Write(bytes.Join(sections, []byte("\n")))
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// warn Write a warning about the entry being converted
+func (c *blueprintConverter) warn(format string, a ...interface{}) {
+	fmt.Fprintf(c.log, "Warning: %s: %s\n", c.entry, fmt.Sprintf(format, a...))
+}
+
+// entryLabel Name of an entry's resource, from its `id` or the identifiers
+func (c *blueprintConverter) entryLabel(e *blueprintEntry) string {
+	if e.ID != "" {
+		return e.ID
+	}
+	// Bindings are named after the entry they're bound to, like in exports
+	if target, ok := e.Identifiers["target"]; ok && target.Tag == "!KeyOf" {
+		if order, ok := e.Identifiers["order"]; ok {
+			return fmt.Sprintf("%s_%s", target.Value, order.Value)
+		}
+	}
+	for _, field := range []string{"slug", "name", "username", "domain"} {
+		for _, values := range []map[string]yaml.Node{e.Identifiers, e.Attrs} {
+			if n, ok := values[field]; ok && n.Kind == yaml.ScalarNode && n.ShortTag() == "!!str" {
+				return n.Value
+			}
+		}
+	}
+	return ""
+}
+
+// label Get a unique name for a resource or data source
+func (c *blueprintConverter) label(kind string, typ string, label string) string {
+	label = exportSanitizeLabel(label, typ)
+	unique := label
+	for n := 2; c.labels[kind+"."+typ+"."+unique]; n++ {
+		unique = fmt.Sprintf("%s_%d", label, n)
+	}
+	c.labels[kind+"."+typ+"."+unique] = true
+	return unique
+}
+
+// convertEntry Write the resource of an entry, with the identifiers and attrs as attributes
+func (c *blueprintConverter) convertEntry(e *blueprintEntry) {
+	block := c.resources.Body().AppendNewBlock("resource", []string{e.resource, e.label})
+	s := c.p.ResourcesMap[e.resource].Schema
+
+	values := map[string]yaml.Node{}
+	for k, v := range e.Attrs {
+		values[k] = v
+	}
+	// Identifiers take precedence, as they're used to find the object before the attrs are applied
+	for k, v := range e.Identifiers {
+		values[k] = v
+	}
+	attrs := map[string]hclwrite.Tokens{}
+	for _, field := range sortedKeys(values) {
+		if field == "pk" {
+			c.warn("the primary key can't be set, import the object instead")
+			continue
+		}
+		key, ok := blueprintSchemaKey(s, field)
+		if !ok {
+			c.warn("attribute %q isn't supported by %s, it was skipped", field, e.resource)
+			continue
+		}
+		value := values[field]
+		tokens := c.value(&value)
+		if tokens == nil {
+			continue
+		}
+		ks := s[key]
+		if ks.DiffSuppressFunc != nil && reflect.ValueOf(ks.DiffSuppressFunc).Pointer() == reflect.ValueOf(diffSuppressJSON).Pointer() && value.Kind != yaml.ScalarNode {
+			tokens = hclwrite.TokensForFunctionCall("jsonencode", tokens)
+		}
+		attrs[key] = tokens
+	}
+	for _, key := range sortedKeys(attrs) {
+		block.Body().SetAttributeRaw(key, attrs[key])
+	}
+
+	if len(e.Conditions) > 0 {
+		c.warn("conditions aren't supported, the resource is always created")
+	}
+	if e.State == "created" {
+		// The object is only created, and not updated afterwards
+		block.Body().AppendNewline()
+		lifecycle := block.Body().AppendNewBlock("lifecycle", nil)
+		lifecycle.Body().SetAttributeRaw("ignore_changes", hclwrite.Tokens{{Type: hclsyntax.TokenIdent, Bytes: []byte("all")}})
+	}
+}
+
+// blueprintSchemaKey Find the attribute a field of a blueprint entry is set with
+func blueprintSchemaKey(s map[string]*schema.Schema, field string) (string, bool) {
+	for _, key := range append([]string{field}, apiFieldAliases[field]...) {
+		if ks, ok := s[key]; ok && (ks.Optional || ks.Required) {
+			return key, true
+		}
+	}
+	return "", false
+}
+
+// value Convert a YAML value, returns nil if it can't be converted
+func (c *blueprintConverter) value(n *yaml.Node) hclwrite.Tokens {
+	if n.Kind == yaml.AliasNode {
+		return c.value(n.Alias)
+	}
+	switch n.Tag {
+	case "!KeyOf":
+		ref, ok := c.keys[n.Value]
+		if !ok {
+			c.warn("`!KeyOf %s` doesn't refer to an entry of the blueprint", n.Value)
+			return nil
+		}
+		return exportReference(ref)
+	case "!Find":
+		return c.find(n)
+	case "!Context":
+		return c.variable(n, "", func(key string) (*yaml.Node, string) {
+			description := fmt.Sprintf("Value of `%s` from the context of the blueprint", key)
+			if def, ok := c.context[key]; ok {
+				return &def, description
+			}
+			return nil, description
+		})
+	case "!Env":
+		return c.variable(n, "env_", func(key string) (*yaml.Node, string) {
+			return nil, fmt.Sprintf("Value of the environment variable `%s` used by the blueprint", key)
+		})
+	case "!Format":
+		if n.Kind != yaml.SequenceNode || len(n.Content) < 1 {
+			c.warn("invalid `!Format`, it was skipped")
+			return nil
+		}
+		args := make([]hclwrite.Tokens, 0, len(n.Content))
+		for _, a := range n.Content {
+			tokens := c.value(a)
+			if tokens == nil {
+				return nil
+			}
+			args = append(args, tokens)
+		}
+		return hclwrite.TokensForFunctionCall("format", args...)
+	}
+	if strings.HasPrefix(n.Tag, "!") && !strings.HasPrefix(n.Tag, "!!") {
+		c.warn("the `%s` tag isn't supported, the value was skipped", n.Tag)
+		return nil
+	}
+
+	switch n.Kind {
+	case yaml.SequenceNode:
+		elems := make([]hclwrite.Tokens, 0, len(n.Content))
+		for _, e := range n.Content {
+			if tokens := c.value(e); tokens != nil {
+				elems = append(elems, tokens)
+			}
+		}
+		return hclwrite.TokensForTuple(elems)
+	case yaml.MappingNode:
+		attrs := make([]hclwrite.ObjectAttrTokens, 0, len(n.Content)/2)
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			tokens := c.value(n.Content[i+1])
+			if tokens == nil {
+				continue
+			}
+			attrs = append(attrs, hclwrite.ObjectAttrTokens{
+				Name:  hclwrite.TokensForValue(cty.StringVal(n.Content[i].Value)),
+				Value: tokens,
+			})
+		}
+		return hclwrite.TokensForObject(attrs)
+	}
+	switch n.ShortTag() {
+	case "!!null":
+		return hclwrite.TokensForValue(cty.NullVal(cty.DynamicPseudoType))
+	case "!!bool":
+		var b bool
+		if err := n.Decode(&b); err == nil {
+			return hclwrite.TokensForValue(cty.BoolVal(b))
+		}
+	case "!!int", "!!float":
+		if f, err := strconv.ParseFloat(n.Value, 64); err == nil {
+			return hclwrite.TokensForValue(cty.NumberFloatVal(f))
+		}
+	}
+	return hclwrite.TokensForValue(cty.StringVal(n.Value))
+}
+
+// variable Convert `!Context key`, `!Env name` or their `[key, default]` forms to a variable
+func (c *blueprintConverter) variable(n *yaml.Node, prefix string, lookup func(key string) (*yaml.Node, string)) hclwrite.Tokens {
+	key := n.Value
+	var def *yaml.Node
+	if n.Kind == yaml.SequenceNode && len(n.Content) > 0 {
+		key = n.Content[0].Value
+		if len(n.Content) > 1 {
+			def = n.Content[1]
+		}
+	}
+	if key == "" {
+		c.warn("invalid `%s`, it was skipped", n.Tag)
+		return nil
+	}
+	defined, description := lookup(key)
+	if def == nil {
+		def = defined
+	}
+	name := exportSanitizeLabel(prefix+key, "")
+	if !c.labels["variable."+name] {
+		c.labels["variable."+name] = true
+		if len(c.variables.Body().Blocks()) > 0 {
+			c.variables.Body().AppendNewline()
+		}
+		block := c.variables.Body().AppendNewBlock("variable", []string{name})
+		block.Body().SetAttributeValue("description", cty.StringVal(description))
+		if def != nil {
+			if tokens := c.value(def); tokens != nil {
+				block.Body().SetAttributeRaw("default", tokens)
+			}
+		}
+	}
+	return hclwrite.TokensForTraversal(hcl.Traversal{
+		hcl.TraverseRoot{Name: "var"},
+		hcl.TraverseAttr{Name: name},
+	})
+}
+
+// find Convert `!Find [model, [field, value], ...]` to a data source
+func (c *blueprintConverter) find(n *yaml.Node) hclwrite.Tokens {
+	if n.Kind != yaml.SequenceNode || len(n.Content) < 2 {
+		c.warn("invalid `!Find`, it was skipped")
+		return nil
+	}
+	model := n.Content[0].Value
+	ds, ok := blueprintFindDataSources[model]
+	if !ok {
+		c.warn("`!Find` of model %q isn't supported, the value was skipped", model)
+		return nil
+	}
+	s := c.p.DataSourcesMap[ds].Schema
+	args := map[string]hclwrite.Tokens{}
+	labels := []string{}
+	for _, q := range n.Content[1:] {
+		if q.Kind != yaml.SequenceNode || len(q.Content) != 2 {
+			c.warn("invalid `!Find` query, it was skipped")
+			return nil
+		}
+		field := q.Content[0].Value
+		if ks, ok := s[field]; !ok || !ks.Optional {
+			c.warn("`!Find` by %q isn't supported by the %s data source, the value was skipped", field, ds)
+			return nil
+		}
+		tokens := c.value(q.Content[1])
+		if tokens == nil {
+			return nil
+		}
+		args[field] = tokens
+		labels = append(labels, q.Content[1].Value)
+	}
+
+	key := ds + ":"
+	for _, field := range sortedKeys(args) {
+		key += field + "=" + string(args[field].Bytes()) + ";"
+	}
+	if ref, ok := c.finds[key]; ok {
+		return exportReference(ref)
+	}
+	label := c.label("data", ds, strings.Join(labels, "_"))
+	if len(c.dataSources.Body().Blocks()) > 0 {
+		c.dataSources.Body().AppendNewline()
+	}
+	block := c.dataSources.Body().AppendNewBlock("data", []string{ds, label})
+	for _, field := range sortedKeys(args) {
+		block.Body().SetAttributeRaw(field, args[field])
+	}
+	c.finds[key] = fmt.Sprintf("data.%s.%s.id", ds, label)
+	return exportReference(c.finds[key])
+}
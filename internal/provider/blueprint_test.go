@@ -0,0 +1,125 @@
+package provider
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/hashicorp/hcl/v2/hclparse"
+	"github.com/stretchr/testify/assert"
+)
+
+const testBlueprint = `
+version: 1
+metadata:
+  name: Example - Authentication flow
+context:
+  domain: authentik.company
+entries:
+  - model: authentik_flows.flow
+    id: flow
+    identifiers:
+      slug: example-authentication-flow
+    attrs:
+      name: Welcome to authentik!
+      title: Welcome to authentik!
+      designation: authentication
+      pk: 5
+  - model: authentik_stages_password.passwordstage
+    id: password-stage
+    identifiers:
+      name: example-authentication-password
+    attrs:
+      backends:
+        - authentik.core.auth.InbuiltBackend
+      failed_attempts_before_cancel: 5
+  - model: authentik_flows.flowstagebinding
+    identifiers:
+      order: 20
+      stage: !KeyOf password-stage
+      target: !KeyOf flow
+  - model: authentik_core.group
+    state: created
+    identifiers:
+      name: !Format ["%s admins", !Context domain]
+    attrs:
+      parent: !Find [authentik_core.group, [name, authentik Admins]]
+      attributes:
+        notes: !Env [NOTES, none]
+        settings:
+          locale: en
+  - model: authentik_core.group
+    identifiers:
+      name: readers
+    attrs:
+      parent: !Find [authentik_core.group, [name, authentik Admins]]
+      is_superuser: !If [true, true, false]
+      color: blue
+    conditions:
+      - true
+  - model: authentik_events.event
+    identifiers:
+      action: custom
+  - model: authentik_stages_dummy.dummystage
+    state: absent
+    identifiers:
+      name: removed
+`
+
+func Test_ConvertBlueprint(t *testing.T) {
+	out := &bytes.Buffer{}
+	log := &bytes.Buffer{}
+	assert.NoError(t, ConvertBlueprint([]byte(testBlueprint), out, log))
+
+	_, diags := hclparse.NewParser().ParseHCL(out.Bytes(), "blueprint.tf")
+	assert.False(t, diags.HasErrors(), diags.Error())
+	hcl := out.String()
+	assert.Contains(t, hcl, "# Converted from the authentik blueprint \"Example - Authentication flow\"")
+	assert.Contains(t, hcl, `resource "authentik_flow" "flow" {`)
+	assert.Regexp(t, `designation\s+= "authentication"`, hcl)
+	assert.Regexp(t, `backends\s+= \["authentik.core.auth.InbuiltBackend"\]`, hcl)
+	assert.Regexp(t, `failed_attempts_before_cancel\s+= 5\n`, hcl)
+
+	// References to other entries use the UUID of flows, and the ID of other resources
+	assert.Contains(t, hcl, `resource "authentik_flow_stage_binding" "flow_20" {`)
+	assert.Regexp(t, `stage\s+= authentik_stage_password.password-stage.id\n`, hcl)
+	assert.Regexp(t, `target\s+= authentik_flow.flow.uuid\n`, hcl)
+
+	// Lookups are converted to data sources, and context and environment values to variables
+	assert.Contains(t, hcl, `data "authentik_group" "authentik_admins" {`)
+	assert.Equal(t, 1, bytes.Count(out.Bytes(), []byte(`data "authentik_group"`)))
+	assert.Regexp(t, `parent\s+= data.authentik_group.authentik_admins.id\n`, hcl)
+	assert.Regexp(t, `name\s+= format\("%s admins", var.domain\)`, hcl)
+	assert.Contains(t, hcl, "variable \"domain\" {\n  description = \"Value of `domain` from the context of the blueprint\"\n  default     = \"authentik.company\"\n}")
+	assert.Contains(t, hcl, `default     = "none"`)
+	assert.Contains(t, hcl, `attributes = jsonencode({`)
+	assert.Contains(t, hcl, `"notes" = var.env_notes`)
+	assert.Contains(t, hcl, "lifecycle {\n    ignore_changes = all\n  }")
+
+	warnings := log.String()
+	assert.Contains(t, warnings, `the primary key can't be set`)
+	assert.Contains(t, warnings, `attribute "color" isn't supported by authentik_group`)
+	assert.Contains(t, warnings, "the `!If` tag isn't supported")
+	assert.Contains(t, warnings, "conditions aren't supported")
+	assert.Contains(t, warnings, `model "authentik_events.event" isn't supported`)
+	assert.Contains(t, warnings, "`state: absent` can't be converted")
+	assert.NotContains(t, hcl, "removed")
+}
+
+func Test_ConvertBlueprint_Invalid(t *testing.T) {
+	assert.Error(t, ConvertBlueprint([]byte("entries: foo"), &bytes.Buffer{}, nil))
+
+	log := &bytes.Buffer{}
+	out := &bytes.Buffer{}
+	assert.NoError(t, ConvertBlueprint([]byte(`
+entries:
+  - model: authentik_flows.flowstagebinding
+    identifiers:
+      target: !KeyOf missing
+      stage: !Find [authentik_stages_dummy.dummystage, [name, foo]]
+      order: !Find [authentik_flows.flow, [title, foo]]
+`), out, log))
+	assert.Contains(t, log.String(), "`!KeyOf missing` doesn't refer to an entry of the blueprint")
+	assert.Contains(t, log.String(), "`!Find` of model \"authentik_stages_dummy.dummystage\" isn't supported")
+	assert.Contains(t, log.String(), "`!Find` by \"title\" isn't supported by the authentik_flow data source")
+	assert.Equal(t, "resource \"authentik_flow_stage_binding\" \"missing\" {\n}\n", out.String())
+}
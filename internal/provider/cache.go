@@ -0,0 +1,179 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// cacheTransport Transport that caches the responses of GET requests for a short time, so that data
+// sources and resources looking up the same objects or lists only send one request. Concurrent
+// requests for the same URL are de-duplicated. Any other request is treated as a write and
+// invalidates the cached responses of its API group, like `/core/` or `/propertymappings/`, as
+// writes to one collection can show up in others, like the members of a group. A single instance
+// is used by all resources and data sources of a provider.
+type cacheTransport struct {
+	inner http.RoundTripper
+	ttl   time.Duration
+
+	mu      sync.Mutex
+	entries map[string]*cacheEntry
+	calls   map[string]*cacheCall
+	// generation is increased by every write, responses fetched across a write aren't cached
+	generation uint64
+}
+
+// cacheEntry Response of a GET request
+type cacheEntry struct {
+	group      string
+	status     string
+	statusCode int
+	header     http.Header
+	body       []byte
+	expires    time.Time
+}
+
+// cacheCall GET request which is in flight, requests for the same URL wait for it
+type cacheCall struct {
+	done  chan struct{}
+	entry *cacheEntry
+	err   error
+}
+
+// NewCacheTransport Get a HTTP transport which caches GET responses for ttl. Caching is disabled when
+// ttl is 0.
+func NewCacheTransport(inner http.RoundTripper, ttl time.Duration) *cacheTransport {
+	return &cacheTransport{
+		inner:   inner,
+		ttl:     ttl,
+		entries: make(map[string]*cacheEntry),
+		calls:   make(map[string]*cacheCall),
+	}
+}
+
+// cacheGroup API group of a path, like `core` for `/api/v3/core/users/1/`
+func cacheGroup(path string) string {
+	if i := strings.Index(path, "/api/v3/"); i >= 0 {
+		path = path[i+len("/api/v3/"):]
+	}
+	return strings.SplitN(strings.TrimPrefix(path, "/"), "/", 2)[0]
+}
+
+// invalidate Remove the cached responses of an API group
+func (ct *cacheTransport) invalidate(group string) {
+	ct.mu.Lock()
+	defer ct.mu.Unlock()
+	ct.generation += 1
+	for key, entry := range ct.entries {
+		if entry.group == group {
+			delete(ct.entries, key)
+		}
+	}
+}
+
+// fetch Send a GET request and read its response
+func (ct *cacheTransport) fetch(r *http.Request, group string) (*cacheEntry, error) {
+	res, err := ct.inner.RoundTrip(r)
+	if err != nil {
+		return nil, err
+	}
+	body, err := io.ReadAll(res.Body)
+	_ = res.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	return &cacheEntry{
+		group:      group,
+		status:     res.Status,
+		statusCode: res.StatusCode,
+		header:     res.Header,
+		body:       body,
+		expires:    time.Now().Add(ct.ttl),
+	}, nil
+}
+
+// response Build a response from a cached entry, every response gets its own body
+func (e *cacheEntry) response(r *http.Request) *http.Response {
+	return &http.Response{
+		Status:        e.status,
+		StatusCode:    e.statusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        e.header.Clone(),
+		Body:          io.NopCloser(bytes.NewReader(e.body)),
+		ContentLength: int64(len(e.body)),
+		Request:       r,
+	}
+}
+
+// RoundTrip HTTP Transport
+func (ct *cacheTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	if ct.ttl <= 0 {
+		return ct.inner.RoundTrip(r)
+	}
+	group := cacheGroup(r.URL.Path)
+	if r.Method != http.MethodGet {
+		res, err := ct.inner.RoundTrip(r)
+		ct.invalidate(group)
+		return res, err
+	}
+
+	key := r.URL.String()
+	for {
+		ct.mu.Lock()
+		if entry, ok := ct.entries[key]; ok && time.Now().Before(entry.expires) {
+			ct.mu.Unlock()
+			tflog.Debug(r.Context(), "authentik: API response served from cache", map[string]interface{}{
+				"http_method": r.Method,
+				"http_path":   r.URL.Path,
+			})
+			return entry.response(r), nil
+		}
+		call, ok := ct.calls[key]
+		if !ok {
+			// The lock is held until this request is registered as in flight
+			break
+		}
+		ct.mu.Unlock()
+		select {
+		case <-call.done:
+		case <-r.Context().Done():
+			return nil, r.Context().Err()
+		}
+		if call.err != nil {
+			if errors.Is(call.err, context.Canceled) || errors.Is(call.err, context.DeadlineExceeded) {
+				// The request that was waited for was cancelled or timed out, not this one
+				continue
+			}
+			return nil, call.err
+		}
+		return call.entry.response(r), nil
+	}
+	call := &cacheCall{done: make(chan struct{})}
+	ct.calls[key] = call
+	generation := ct.generation
+	ct.mu.Unlock()
+
+	call.entry, call.err = ct.fetch(r, group)
+
+	ct.mu.Lock()
+	delete(ct.calls, key)
+	if call.err == nil && call.entry.statusCode < 300 && ct.generation == generation {
+		ct.entries[key] = call.entry
+	}
+	ct.mu.Unlock()
+	close(call.done)
+
+	if call.err != nil {
+		return nil, call.err
+	}
+	return call.entry.response(r), nil
+}
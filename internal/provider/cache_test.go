@@ -0,0 +1,154 @@
+package provider
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// countingTransport Transport which counts requests and answers with the given status code
+type countingTransport struct {
+	mu     sync.Mutex
+	count  int
+	status int
+}
+
+func (ct *countingTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	ct.mu.Lock()
+	ct.count += 1
+	ct.mu.Unlock()
+	return &http.Response{StatusCode: ct.status, Body: io.NopCloser(strings.NewReader(r.URL.String())), Request: r}, nil
+}
+
+func testCacheRequest(t *testing.T, transport http.RoundTripper, method string, path string) string {
+	req, err := http.NewRequest(method, "http://authentik.invalid/api/v3"+path, nil)
+	assert.NoError(t, err)
+	res, err := transport.RoundTrip(req)
+	assert.NoError(t, err)
+	body, err := io.ReadAll(res.Body)
+	assert.NoError(t, err)
+	assert.NoError(t, res.Body.Close())
+	return string(body)
+}
+
+func Test_cacheTransport(t *testing.T) {
+	inner := &countingTransport{status: http.StatusOK}
+	ct := NewCacheTransport(inner, time.Minute)
+
+	assert.Equal(t, "http://authentik.invalid/api/v3/core/users/?page=1", testCacheRequest(t, ct, http.MethodGet, "/core/users/?page=1"))
+	assert.Equal(t, "http://authentik.invalid/api/v3/core/users/?page=1", testCacheRequest(t, ct, http.MethodGet, "/core/users/?page=1"))
+	assert.Equal(t, 1, inner.count)
+	testCacheRequest(t, ct, http.MethodGet, "/core/users/?page=2")
+	testCacheRequest(t, ct, http.MethodGet, "/flows/instances/")
+	assert.Equal(t, 3, inner.count)
+
+	// Writes invalidate the responses of the same API group
+	testCacheRequest(t, ct, http.MethodPost, "/core/groups/")
+	assert.Equal(t, 4, inner.count)
+	testCacheRequest(t, ct, http.MethodGet, "/core/users/?page=1")
+	testCacheRequest(t, ct, http.MethodGet, "/flows/instances/")
+	assert.Equal(t, 5, inner.count)
+
+	// Expired responses are fetched again
+	ct.entries["http://authentik.invalid/api/v3/flows/instances/"].expires = time.Now()
+	testCacheRequest(t, ct, http.MethodGet, "/flows/instances/")
+	assert.Equal(t, 6, inner.count)
+}
+
+func Test_cacheTransport_Errors(t *testing.T) {
+	inner := &countingTransport{status: http.StatusNotFound}
+	ct := NewCacheTransport(inner, time.Minute)
+	testCacheRequest(t, ct, http.MethodGet, "/core/users/1/")
+	testCacheRequest(t, ct, http.MethodGet, "/core/users/1/")
+	assert.Equal(t, 2, inner.count)
+}
+
+func Test_cacheTransport_Disabled(t *testing.T) {
+	inner := &countingTransport{status: http.StatusOK}
+	ct := NewCacheTransport(inner, 0)
+	testCacheRequest(t, ct, http.MethodGet, "/core/users/")
+	testCacheRequest(t, ct, http.MethodGet, "/core/users/")
+	assert.Equal(t, 2, inner.count)
+}
+
+func Test_cacheTransport_SingleFlight(t *testing.T) {
+	inner := &blockingTransport{release: make(chan struct{})}
+	ct := NewCacheTransport(inner, time.Minute)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			testCacheRequest(t, ct, http.MethodGet, "/propertymappings/saml/")
+		}()
+	}
+	// Wait until all requests are waiting for the first one
+	assert.Eventually(t, func() bool {
+		inner.mu.Lock()
+		defer inner.mu.Unlock()
+		return inner.active == 1
+	}, time.Second, time.Millisecond)
+	time.Sleep(10 * time.Millisecond)
+	close(inner.release)
+	wg.Wait()
+	assert.Equal(t, []string{"/api/v3/propertymappings/saml/"}, inner.order)
+}
+
+func Test_cacheGroup(t *testing.T) {
+	assert.Equal(t, "core", cacheGroup("/api/v3/core/users/1/"))
+	assert.Equal(t, "propertymappings", cacheGroup("/authentik/api/v3/propertymappings/saml/"))
+	assert.Equal(t, "core", cacheGroup("/core/groups/"))
+}
+
+// cancellingTransport Transport whose first request blocks until its context ends
+type cancellingTransport struct {
+	mu      sync.Mutex
+	count   int
+	started chan struct{}
+}
+
+func (ct *cancellingTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	ct.mu.Lock()
+	ct.count += 1
+	first := ct.count == 1
+	ct.mu.Unlock()
+	if first {
+		close(ct.started)
+		<-r.Context().Done()
+		return nil, r.Context().Err()
+	}
+	return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader("ok")), Request: r}, nil
+}
+
+func Test_cacheTransport_Cancelled(t *testing.T) {
+	inner := &cancellingTransport{started: make(chan struct{})}
+	ct := NewCacheTransport(inner, time.Minute)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	leader := make(chan error)
+	go func() {
+		req, _ := http.NewRequestWithContext(ctx, http.MethodGet, "http://authentik.invalid/api/v3/core/users/", nil)
+		_, err := ct.RoundTrip(req)
+		leader <- err
+	}()
+	<-inner.started
+	waiter := make(chan string)
+	go func() {
+		waiter <- testCacheRequest(t, ct, http.MethodGet, "/core/users/")
+	}()
+	// Give the waiter time to join the request in flight
+	time.Sleep(10 * time.Millisecond)
+	cancel()
+
+	assert.ErrorIs(t, <-leader, context.Canceled)
+	// The waiter isn't affected by the cancelled request, and fetches the response itself
+	assert.Equal(t, "ok", <-waiter)
+	assert.Equal(t, 2, inner.count)
+}
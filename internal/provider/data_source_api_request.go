@@ -0,0 +1,117 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceAPIRequest() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceAPIRequestRead,
+		Description: "Send a GET request to any endpoint of the authentik API. Paginated responses are fetched completely, with the results of all pages in `results`.",
+		Schema: map[string]*schema.Schema{
+			"path": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Path of the endpoint, relative to `/api/v3`, like `/admin/system/`.",
+			},
+			"query": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Query parameters of the request. When `page` is set, only that page is fetched.",
+			},
+			"response": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "JSON response, use jsondecode() to access nested values.",
+			},
+			"response_map": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Top-level keys of the response, if it's an object. Values which aren't strings are JSON encoded.",
+			},
+		},
+	}
+}
+
+func dataSourceAPIRequestRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	c := m.(*APIClient)
+
+	path := apiPath(d.Get("path").(string))
+	query := url.Values{}
+	for k, v := range d.Get("query").(map[string]interface{}) {
+		query.Set(k, v.(string))
+	}
+	_, singlePage := query["page"]
+
+	var response interface{}
+	results := []interface{}{}
+	for page := 1; true; page++ {
+		if !singlePage {
+			query.Set("page", strconv.Itoa(page))
+		}
+		raw, hr, err := c.apiRequest(ctx, http.MethodGet, path, query, nil)
+		if err != nil {
+			return httpToDiag(d, hr, err)
+		}
+		var res struct {
+			Pagination *struct {
+				Next json.Number `json:"next"`
+			} `json:"pagination"`
+			Results []interface{} `json:"results"`
+		}
+		// Responses which aren't paginated objects, like lists, are returned as-is
+		if err := json.Unmarshal(raw, &res); err != nil || res.Pagination == nil || singlePage {
+			response, err = decodeJSON(raw)
+			if err != nil {
+				return diag.Errorf("failed to decode response: %s", err)
+			}
+			break
+		}
+		results = append(results, res.Results...)
+		if next, _ := res.Pagination.Next.Float64(); next == 0 {
+			obj, _ := decodeJSONObject(raw)
+			delete(obj, "pagination")
+			obj["results"] = results
+			response = obj
+			break
+		}
+	}
+
+	b, err := json.Marshal(response)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	responseMap := map[string]string{}
+	if obj, ok := response.(map[string]interface{}); ok {
+		for k, v := range obj {
+			if s, ok := v.(string); ok {
+				responseMap[k] = s
+				continue
+			}
+			vb, err := json.Marshal(v)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			responseMap[k] = string(vb)
+		}
+	}
+
+	if !singlePage {
+		query.Del("page")
+	}
+	d.SetId(fmt.Sprintf("%s?%s", path, query.Encode()))
+	setWrapper(d, "response", string(b))
+	setWrapper(d, "response_map", responseMap)
+	return diags
+}
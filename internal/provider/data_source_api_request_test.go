@@ -0,0 +1,88 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccDataSourceAPIRequest(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: providerTestFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceAPIRequestSimple,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.authentik_api_request.config", "response_map.capabilities", "[]"),
+					resource.TestCheckResourceAttr("data.authentik_api_request.flows", "response_map.%", "1"),
+				),
+			},
+		},
+	})
+}
+
+const testAccDataSourceAPIRequestSimple = `
+data "authentik_api_request" "config" {
+  path = "/root/config/"
+}
+
+data "authentik_api_request" "flows" {
+  path = "/flows/instances/"
+  query = {
+    designation = "authorization"
+  }
+}
+`
+
+func testDataSourceAPIRequest(t *testing.T, raw map[string]interface{}) *schema.ResourceData {
+	p := testProviderWithConfig(t, map[string]interface{}{})
+	r := p.DataSourcesMap["authentik_api_request"]
+	d := schema.TestResourceDataRaw(t, r.Schema, raw)
+	diags := r.ReadContext(context.Background(), d, p.Meta())
+	assert.False(t, diags.HasError(), diags)
+	return d
+}
+
+func Test_dataSourceAPIRequest(t *testing.T) {
+	d := testDataSourceAPIRequest(t, map[string]interface{}{"path": "root/config"})
+	assert.Equal(t, "/root/config/?", d.Id())
+	assert.Equal(t, "[]", d.Get("response_map.capabilities"))
+	assert.JSONEq(t, `{"enabled": false, "sentry_dsn": "", "environment": "", "send_pii": false, "traces_sample_rate": 0}`, d.Get("response_map.error_reporting").(string))
+
+	// All pages are fetched
+	d = testDataSourceAPIRequest(t, map[string]interface{}{
+		"path":  "/flows/instances/",
+		"query": map[string]interface{}{"designation": "authorization", "page_size": "1"},
+	})
+	assert.Equal(t, "/flows/instances/?designation=authorization&page_size=1", d.Id())
+	var res struct {
+		Pagination interface{}              `json:"pagination"`
+		Results    []map[string]interface{} `json:"results"`
+	}
+	assert.NoError(t, json.Unmarshal([]byte(d.Get("response").(string)), &res))
+	assert.Nil(t, res.Pagination)
+	assert.Len(t, res.Results, 2)
+	assert.Equal(t, "authorization", res.Results[1]["designation"])
+
+	// Unless a page is requested
+	d = testDataSourceAPIRequest(t, map[string]interface{}{
+		"path":  "/flows/instances/",
+		"query": map[string]interface{}{"designation": "authorization", "page_size": "1", "page": "2"},
+	})
+	assert.NoError(t, json.Unmarshal([]byte(d.Get("response").(string)), &res))
+	assert.NotNil(t, res.Pagination)
+	assert.Len(t, res.Results, 1)
+}
+
+func Test_dataSourceAPIRequest_NotFound(t *testing.T) {
+	p := testProviderWithConfig(t, map[string]interface{}{})
+	r := p.DataSourcesMap["authentik_api_request"]
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{"path": "/core/groups/missing/"})
+	diags := r.ReadContext(context.Background(), d, p.Meta())
+	assert.True(t, diags.HasError())
+	assert.Contains(t, diags[0].Summary, "GET /api/v3/core/groups/missing/")
+}
@@ -7,9 +7,10 @@ import (
 )
 
 func TestAccDataSourceCertificateKeyPair(t *testing.T) {
+	testAccCassette(t)
 	resource.UnitTest(t, resource.TestCase{
 		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: providerFactories,
+		ProviderFactories: testAccProviderFactories(),
 		Steps: []resource.TestStep{
 			{
 				Config: testAccDataSourceCertificateKeyPairSimple,
@@ -0,0 +1,853 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/zclconf/go-cty/cty"
+	ctyjson "github.com/zclconf/go-cty/cty/json"
+)
+
+// ExportOptions Options of Export
+type ExportOptions struct {
+	// Dir Directory the Terraform configuration is written to, which is created if it doesn't exist
+	Dir string
+	// IncludeManaged Also export objects which are managed by authentik itself, like the
+	// built-in source or the default property mappings
+	IncludeManaged bool
+	// Log Receives messages about objects which were skipped
+	Log io.Writer
+}
+
+// exportType Resource type which is exported, with the list endpoint its objects are found with
+type exportType struct {
+	resource string
+	// idField JSON field of the listed objects that is used as the resource ID
+	idField string
+	// labelFields JSON fields of the listed objects the resource name is built from
+	labelFields []string
+	// binding Bindings are named after the object they are bound to, so they're labelled last
+	binding bool
+	list    func(ctx context.Context, c *APIClient, page int32) importPage
+}
+
+// exportTypes Resource types which are exported. Tokens and licenses are skipped, as their keys
+// can't be exported in a useful way.
+var exportTypes = []exportType{
+	{
+		resource:    "authentik_application",
+		idField:     "slug",
+		labelFields: []string{"slug"},
+		list: func(ctx context.Context, c *APIClient, page int32) importPage {
+			return importList(c.client.CoreApi.CoreApplicationsList(ctx).Page(page).Execute())
+		},
+	},
+	{
+		resource:    "authentik_blueprint",
+		idField:     "pk",
+		labelFields: []string{"name"},
+		list: func(ctx context.Context, c *APIClient, page int32) importPage {
+			return importList(c.client.ManagedApi.ManagedBlueprintsList(ctx).Page(page).Execute())
+		},
+	},
+	{
+		resource:    "authentik_certificate_key_pair",
+		idField:     "pk",
+		labelFields: []string{"name"},
+		list: func(ctx context.Context, c *APIClient, page int32) importPage {
+			return importList(c.client.CryptoApi.CryptoCertificatekeypairsList(ctx).Page(page).Execute())
+		},
+	},
+	{
+		resource:    "authentik_event_rule",
+		idField:     "pk",
+		labelFields: []string{"name"},
+		list: func(ctx context.Context, c *APIClient, page int32) importPage {
+			return importList(c.client.EventsApi.EventsRulesList(ctx).Page(page).Execute())
+		},
+	},
+	{
+		resource:    "authentik_event_transport",
+		idField:     "pk",
+		labelFields: []string{"name"},
+		list: func(ctx context.Context, c *APIClient, page int32) importPage {
+			return importList(c.client.EventsApi.EventsTransportsList(ctx).Page(page).Execute())
+		},
+	},
+	{
+		resource:    "authentik_flow",
+		idField:     "slug",
+		labelFields: []string{"slug"},
+		list: func(ctx context.Context, c *APIClient, page int32) importPage {
+			return importList(c.client.FlowsApi.FlowsInstancesList(ctx).Page(page).Execute())
+		},
+	},
+	{
+		resource:    "authentik_group",
+		idField:     "pk",
+		labelFields: []string{"name"},
+		list: func(ctx context.Context, c *APIClient, page int32) importPage {
+			return importList(c.client.CoreApi.CoreGroupsList(ctx).Page(page).Execute())
+		},
+	},
+	{
+		resource:    "authentik_outpost",
+		idField:     "pk",
+		labelFields: []string{"name"},
+		list: func(ctx context.Context, c *APIClient, page int32) importPage {
+			return importList(c.client.OutpostsApi.OutpostsInstancesList(ctx).Page(page).Execute())
+		},
+	},
+	{
+		resource:    "authentik_policy_dummy",
+		idField:     "pk",
+		labelFields: []string{"name"},
+		list: func(ctx context.Context, c *APIClient, page int32) importPage {
+			return importList(c.client.PoliciesApi.PoliciesDummyList(ctx).Page(page).Execute())
+		},
+	},
+	{
+		resource:    "authentik_policy_event_matcher",
+		idField:     "pk",
+		labelFields: []string{"name"},
+		list: func(ctx context.Context, c *APIClient, page int32) importPage {
+			return importList(c.client.PoliciesApi.PoliciesEventMatcherList(ctx).Page(page).Execute())
+		},
+	},
+	{
+		resource:    "authentik_policy_expiry",
+		idField:     "pk",
+		labelFields: []string{"name"},
+		list: func(ctx context.Context, c *APIClient, page int32) importPage {
+			return importList(c.client.PoliciesApi.PoliciesPasswordExpiryList(ctx).Page(page).Execute())
+		},
+	},
+	{
+		resource:    "authentik_policy_expression",
+		idField:     "pk",
+		labelFields: []string{"name"},
+		list: func(ctx context.Context, c *APIClient, page int32) importPage {
+			return importList(c.client.PoliciesApi.PoliciesExpressionList(ctx).Page(page).Execute())
+		},
+	},
+	{
+		resource:    "authentik_policy_password",
+		idField:     "pk",
+		labelFields: []string{"name"},
+		list: func(ctx context.Context, c *APIClient, page int32) importPage {
+			return importList(c.client.PoliciesApi.PoliciesPasswordList(ctx).Page(page).Execute())
+		},
+	},
+	{
+		resource:    "authentik_policy_reputation",
+		idField:     "pk",
+		labelFields: []string{"name"},
+		list: func(ctx context.Context, c *APIClient, page int32) importPage {
+			return importList(c.client.PoliciesApi.PoliciesReputationList(ctx).Page(page).Execute())
+		},
+	},
+	{
+		resource:    "authentik_property_mapping_ldap",
+		idField:     "pk",
+		labelFields: []string{"name"},
+		list: func(ctx context.Context, c *APIClient, page int32) importPage {
+			return importList(c.client.PropertymappingsApi.PropertymappingsLdapList(ctx).Page(page).Execute())
+		},
+	},
+	{
+		resource:    "authentik_property_mapping_notification",
+		idField:     "pk",
+		labelFields: []string{"name"},
+		list: func(ctx context.Context, c *APIClient, page int32) importPage {
+			return importList(c.client.PropertymappingsApi.PropertymappingsNotificationList(ctx).Page(page).Execute())
+		},
+	},
+	{
+		resource:    "authentik_property_mapping_saml",
+		idField:     "pk",
+		labelFields: []string{"name"},
+		list: func(ctx context.Context, c *APIClient, page int32) importPage {
+			return importList(c.client.PropertymappingsApi.PropertymappingsSamlList(ctx).Page(page).Execute())
+		},
+	},
+	{
+		resource:    "authentik_property_mapping_scim",
+		idField:     "pk",
+		labelFields: []string{"name"},
+		list: func(ctx context.Context, c *APIClient, page int32) importPage {
+			return importList(c.client.PropertymappingsApi.PropertymappingsScimList(ctx).Page(page).Execute())
+		},
+	},
+	{
+		resource:    "authentik_provider_ldap",
+		idField:     "pk",
+		labelFields: []string{"name"},
+		list: func(ctx context.Context, c *APIClient, page int32) importPage {
+			return importList(c.client.ProvidersApi.ProvidersLdapList(ctx).Page(page).Execute())
+		},
+	},
+	{
+		resource:    "authentik_provider_oauth2",
+		idField:     "pk",
+		labelFields: []string{"name"},
+		list: func(ctx context.Context, c *APIClient, page int32) importPage {
+			return importList(c.client.ProvidersApi.ProvidersOauth2List(ctx).Page(page).Execute())
+		},
+	},
+	{
+		resource:    "authentik_provider_proxy",
+		idField:     "pk",
+		labelFields: []string{"name"},
+		list: func(ctx context.Context, c *APIClient, page int32) importPage {
+			return importList(c.client.ProvidersApi.ProvidersProxyList(ctx).Page(page).Execute())
+		},
+	},
+	{
+		resource:    "authentik_provider_radius",
+		idField:     "pk",
+		labelFields: []string{"name"},
+		list: func(ctx context.Context, c *APIClient, page int32) importPage {
+			return importList(c.client.ProvidersApi.ProvidersRadiusList(ctx).Page(page).Execute())
+		},
+	},
+	{
+		resource:    "authentik_provider_saml",
+		idField:     "pk",
+		labelFields: []string{"name"},
+		list: func(ctx context.Context, c *APIClient, page int32) importPage {
+			return importList(c.client.ProvidersApi.ProvidersSamlList(ctx).Page(page).Execute())
+		},
+	},
+	{
+		resource:    "authentik_provider_scim",
+		idField:     "pk",
+		labelFields: []string{"name"},
+		list: func(ctx context.Context, c *APIClient, page int32) importPage {
+			return importList(c.client.ProvidersApi.ProvidersScimList(ctx).Page(page).Execute())
+		},
+	},
+	{
+		resource:    "authentik_scope_mapping",
+		idField:     "pk",
+		labelFields: []string{"name"},
+		list: func(ctx context.Context, c *APIClient, page int32) importPage {
+			return importList(c.client.PropertymappingsApi.PropertymappingsScopeList(ctx).Page(page).Execute())
+		},
+	},
+	{
+		resource:    "authentik_service_connection_docker",
+		idField:     "pk",
+		labelFields: []string{"name"},
+		list: func(ctx context.Context, c *APIClient, page int32) importPage {
+			return importList(c.client.OutpostsApi.OutpostsServiceConnectionsDockerList(ctx).Page(page).Execute())
+		},
+	},
+	{
+		resource:    "authentik_service_connection_kubernetes",
+		idField:     "pk",
+		labelFields: []string{"name"},
+		list: func(ctx context.Context, c *APIClient, page int32) importPage {
+			return importList(c.client.OutpostsApi.OutpostsServiceConnectionsKubernetesList(ctx).Page(page).Execute())
+		},
+	},
+	{
+		resource:    "authentik_source_ldap",
+		idField:     "slug",
+		labelFields: []string{"slug"},
+		list: func(ctx context.Context, c *APIClient, page int32) importPage {
+			return importList(c.client.SourcesApi.SourcesLdapList(ctx).Page(page).Execute())
+		},
+	},
+	{
+		resource:    "authentik_source_oauth",
+		idField:     "slug",
+		labelFields: []string{"slug"},
+		list: func(ctx context.Context, c *APIClient, page int32) importPage {
+			return importList(c.client.SourcesApi.SourcesOauthList(ctx).Page(page).Execute())
+		},
+	},
+	{
+		resource:    "authentik_source_plex",
+		idField:     "slug",
+		labelFields: []string{"slug"},
+		list: func(ctx context.Context, c *APIClient, page int32) importPage {
+			return importList(c.client.SourcesApi.SourcesPlexList(ctx).Page(page).Execute())
+		},
+	},
+	{
+		resource:    "authentik_source_saml",
+		idField:     "slug",
+		labelFields: []string{"slug"},
+		list: func(ctx context.Context, c *APIClient, page int32) importPage {
+			return importList(c.client.SourcesApi.SourcesSamlList(ctx).Page(page).Execute())
+		},
+	},
+	{
+		resource:    "authentik_stage_authenticator_duo",
+		idField:     "pk",
+		labelFields: []string{"name"},
+		list: func(ctx context.Context, c *APIClient, page int32) importPage {
+			return importList(c.client.StagesApi.StagesAuthenticatorDuoList(ctx).Page(page).Execute())
+		},
+	},
+	{
+		resource:    "authentik_stage_authenticator_sms",
+		idField:     "pk",
+		labelFields: []string{"name"},
+		list: func(ctx context.Context, c *APIClient, page int32) importPage {
+			return importList(c.client.StagesApi.StagesAuthenticatorSmsList(ctx).Page(page).Execute())
+		},
+	},
+	{
+		resource:    "authentik_stage_authenticator_static",
+		idField:     "pk",
+		labelFields: []string{"name"},
+		list: func(ctx context.Context, c *APIClient, page int32) importPage {
+			return importList(c.client.StagesApi.StagesAuthenticatorStaticList(ctx).Page(page).Execute())
+		},
+	},
+	{
+		resource:    "authentik_stage_authenticator_totp",
+		idField:     "pk",
+		labelFields: []string{"name"},
+		list: func(ctx context.Context, c *APIClient, page int32) importPage {
+			return importList(c.client.StagesApi.StagesAuthenticatorTotpList(ctx).Page(page).Execute())
+		},
+	},
+	{
+		resource:    "authentik_stage_authenticator_validate",
+		idField:     "pk",
+		labelFields: []string{"name"},
+		list: func(ctx context.Context, c *APIClient, page int32) importPage {
+			return importList(c.client.StagesApi.StagesAuthenticatorValidateList(ctx).Page(page).Execute())
+		},
+	},
+	{
+		resource:    "authentik_stage_authenticator_webauthn",
+		idField:     "pk",
+		labelFields: []string{"name"},
+		list: func(ctx context.Context, c *APIClient, page int32) importPage {
+			return importList(c.client.StagesApi.StagesAuthenticatorWebauthnList(ctx).Page(page).Execute())
+		},
+	},
+	{
+		resource:    "authentik_stage_captcha",
+		idField:     "pk",
+		labelFields: []string{"name"},
+		list: func(ctx context.Context, c *APIClient, page int32) importPage {
+			return importList(c.client.StagesApi.StagesCaptchaList(ctx).Page(page).Execute())
+		},
+	},
+	{
+		resource:    "authentik_stage_consent",
+		idField:     "pk",
+		labelFields: []string{"name"},
+		list: func(ctx context.Context, c *APIClient, page int32) importPage {
+			return importList(c.client.StagesApi.StagesConsentList(ctx).Page(page).Execute())
+		},
+	},
+	{
+		resource:    "authentik_stage_deny",
+		idField:     "pk",
+		labelFields: []string{"name"},
+		list: func(ctx context.Context, c *APIClient, page int32) importPage {
+			return importList(c.client.StagesApi.StagesDenyList(ctx).Page(page).Execute())
+		},
+	},
+	{
+		resource:    "authentik_stage_dummy",
+		idField:     "pk",
+		labelFields: []string{"name"},
+		list: func(ctx context.Context, c *APIClient, page int32) importPage {
+			return importList(c.client.StagesApi.StagesDummyList(ctx).Page(page).Execute())
+		},
+	},
+	{
+		resource:    "authentik_stage_email",
+		idField:     "pk",
+		labelFields: []string{"name"},
+		list: func(ctx context.Context, c *APIClient, page int32) importPage {
+			return importList(c.client.StagesApi.StagesEmailList(ctx).Page(page).Execute())
+		},
+	},
+	{
+		resource:    "authentik_stage_identification",
+		idField:     "pk",
+		labelFields: []string{"name"},
+		list: func(ctx context.Context, c *APIClient, page int32) importPage {
+			return importList(c.client.StagesApi.StagesIdentificationList(ctx).Page(page).Execute())
+		},
+	},
+	{
+		resource:    "authentik_stage_invitation",
+		idField:     "pk",
+		labelFields: []string{"name"},
+		list: func(ctx context.Context, c *APIClient, page int32) importPage {
+			return importList(c.client.StagesApi.StagesInvitationStagesList(ctx).Page(page).Execute())
+		},
+	},
+	{
+		resource:    "authentik_stage_password",
+		idField:     "pk",
+		labelFields: []string{"name"},
+		list: func(ctx context.Context, c *APIClient, page int32) importPage {
+			return importList(c.client.StagesApi.StagesPasswordList(ctx).Page(page).Execute())
+		},
+	},
+	{
+		resource:    "authentik_stage_prompt",
+		idField:     "pk",
+		labelFields: []string{"name"},
+		list: func(ctx context.Context, c *APIClient, page int32) importPage {
+			return importList(c.client.StagesApi.StagesPromptStagesList(ctx).Page(page).Execute())
+		},
+	},
+	{
+		resource:    "authentik_stage_prompt_field",
+		idField:     "pk",
+		labelFields: []string{"name"},
+		list: func(ctx context.Context, c *APIClient, page int32) importPage {
+			return importList(c.client.StagesApi.StagesPromptPromptsList(ctx).Page(page).Execute())
+		},
+	},
+	{
+		resource:    "authentik_stage_user_delete",
+		idField:     "pk",
+		labelFields: []string{"name"},
+		list: func(ctx context.Context, c *APIClient, page int32) importPage {
+			return importList(c.client.StagesApi.StagesUserDeleteList(ctx).Page(page).Execute())
+		},
+	},
+	{
+		resource:    "authentik_stage_user_login",
+		idField:     "pk",
+		labelFields: []string{"name"},
+		list: func(ctx context.Context, c *APIClient, page int32) importPage {
+			return importList(c.client.StagesApi.StagesUserLoginList(ctx).Page(page).Execute())
+		},
+	},
+	{
+		resource:    "authentik_stage_user_logout",
+		idField:     "pk",
+		labelFields: []string{"name"},
+		list: func(ctx context.Context, c *APIClient, page int32) importPage {
+			return importList(c.client.StagesApi.StagesUserLogoutList(ctx).Page(page).Execute())
+		},
+	},
+	{
+		resource:    "authentik_stage_user_write",
+		idField:     "pk",
+		labelFields: []string{"name"},
+		list: func(ctx context.Context, c *APIClient, page int32) importPage {
+			return importList(c.client.StagesApi.StagesUserWriteList(ctx).Page(page).Execute())
+		},
+	},
+	{
+		resource:    "authentik_tenant",
+		idField:     "tenant_uuid",
+		labelFields: []string{"domain"},
+		list: func(ctx context.Context, c *APIClient, page int32) importPage {
+			return importList(c.client.CoreApi.CoreTenantsList(ctx).Page(page).Execute())
+		},
+	},
+	{
+		resource:    "authentik_user",
+		idField:     "pk",
+		labelFields: []string{"username"},
+		list: func(ctx context.Context, c *APIClient, page int32) importPage {
+			return importList(c.client.CoreApi.CoreUsersList(ctx).Page(page).Execute())
+		},
+	},
+	{
+		resource: "authentik_flow_stage_binding",
+		idField:  "pk",
+		binding:  true,
+		list: func(ctx context.Context, c *APIClient, page int32) importPage {
+			return importList(c.client.FlowsApi.FlowsBindingsList(ctx).Page(page).Execute())
+		},
+	},
+	{
+		resource: "authentik_policy_binding",
+		idField:  "pk",
+		binding:  true,
+		list: func(ctx context.Context, c *APIClient, page int32) importPage {
+			return importList(c.client.PoliciesApi.PoliciesBindingsList(ctx).Page(page).Execute())
+		},
+	},
+}
+
+// exportObject Object which is exported as a resource
+type exportObject struct {
+	typ   exportType
+	id    string
+	label string
+	d     *schema.ResourceData
+}
+
+// address Address of the resource in the exported configuration
+func (o *exportObject) address() string {
+	return fmt.Sprintf("%s.%s", o.typ.resource, o.label)
+}
+
+// exportUUID UUIDs are replaced with references when they're the ID of an exported object.
+// Other string values, like slugs, aren't replaced, as they might coincidentally be equal.
+var exportUUID = regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$`)
+
+// exportIntReferences Integer attributes which reference objects, by the kind of object they reference
+var exportIntReferences = map[string]string{
+	"protocol_provider":     "provider",
+	"protocol_providers":    "provider",
+	"backchannel_providers": "provider",
+	"users":                 "user",
+	"user":                  "user",
+}
+
+// exportSkipAttributes Attributes which aren't exported for a resource type. Group memberships are
+// only exported on the groups, as references in both directions would form a cycle.
+var exportSkipAttributes = map[string][]string{
+	"authentik_user": {"groups"},
+}
+
+// Export Write the objects of an authentik instance as Terraform configuration, with `import` blocks
+// for all objects. The instance is connected to with the same settings and environment variables as
+// the provider. The output is sorted, so that exports of the same instance can be compared.
+func Export(ctx context.Context, version string, opts ExportOptions) error {
+	p := Provider(version, false)
+	diags := p.Configure(ctx, terraform.NewResourceConfigRaw(map[string]interface{}{}))
+	if diags.HasError() {
+		return fmt.Errorf("failed to configure provider: %s", diags[0].Summary)
+	}
+	return export(ctx, p, opts)
+}
+
+func export(ctx context.Context, p *schema.Provider, opts ExportOptions) error {
+	if opts.Log == nil {
+		opts.Log = io.Discard
+	}
+	c := p.Meta().(*APIClient)
+	objects := []*exportObject{}
+	for _, t := range exportTypes {
+		r := p.ResourcesMap[t.resource]
+		for page := int32(1); true; page++ {
+			results, next, err := t.list(ctx, c, page).decode()
+			if err != nil {
+				return fmt.Errorf("failed to list %s: %w", t.resource, err)
+			}
+			for _, res := range results {
+				id := fmt.Sprint(res[t.idField])
+				if !opts.IncludeManaged && exportIsManaged(res) {
+					fmt.Fprintf(opts.Log, "Skipping managed %s %s\n", t.resource, id)
+					continue
+				}
+				d := r.Data(nil)
+				d.SetId(id)
+				diags := r.ReadContext(ctx, d, c)
+				if diags.HasError() || d.Id() == "" {
+					fmt.Fprintf(opts.Log, "Skipping %s %s, it couldn't be read: %v\n", t.resource, id, diags)
+					continue
+				}
+				o := &exportObject{typ: t, id: id, d: d}
+				for _, f := range t.labelFields {
+					if v, ok := res[f].(string); ok && v != "" {
+						o.label = v
+						break
+					}
+				}
+				objects = append(objects, o)
+			}
+			if !next {
+				break
+			}
+		}
+	}
+
+	refs := exportLabel(objects)
+	files := map[string]*hclwrite.File{}
+	imports := hclwrite.NewEmptyFile()
+	variables := hclwrite.NewEmptyFile()
+	for _, o := range objects {
+		f, ok := files[o.typ.resource]
+		if !ok {
+			f = hclwrite.NewEmptyFile()
+			files[o.typ.resource] = f
+		} else {
+			f.Body().AppendNewline()
+		}
+		block := f.Body().AppendNewBlock("resource", []string{o.typ.resource, o.label})
+		exportAttributes(block.Body(), variables.Body(), p.ResourcesMap[o.typ.resource].Schema, o, refs)
+
+		if len(imports.Body().Blocks()) > 0 {
+			imports.Body().AppendNewline()
+		}
+		ib := imports.Body().AppendNewBlock("import", nil).Body()
+		ib.SetAttributeTraversal("to", hcl.Traversal{
+			hcl.TraverseRoot{Name: o.typ.resource},
+			hcl.TraverseAttr{Name: o.label},
+		})
+		ib.SetAttributeValue("id", cty.StringVal(o.id))
+	}
+
+	versions := hclwrite.NewEmptyFile()
+	tf := versions.Body().AppendNewBlock("terraform", nil).Body()
+	rp := tf.AppendNewBlock("required_providers", nil).Body()
+	rp.SetAttributeValue("authentik", cty.ObjectVal(map[string]cty.Value{
+		"source": cty.StringVal("goauthentik/authentik"),
+	}))
+	files["versions"] = versions
+	files["imports"] = imports
+	if len(variables.Body().Blocks()) > 0 {
+		files["variables"] = variables
+	}
+
+	err := os.MkdirAll(opts.Dir, 0o755)
+	if err != nil {
+		return err
+	}
+	for name, f := range files {
+		err := os.WriteFile(filepath.Join(opts.Dir, name+".tf"), hclwrite.Format(f.Bytes()), 0o644)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// exportIsManaged Check if a listed object is managed by authentik itself
+func exportIsManaged(res map[string]interface{}) bool {
+	if m, ok := res["managed"].(string); ok && m != "" {
+		return true
+	}
+	// Service accounts of outposts are created by authentik
+	return res["type"] == "internal_service_account"
+}
+
+// exportLabel Assign unique resource names to all objects and sort them by type and name. Returns
+// the references to objects by their IDs.
+func exportLabel(objects []*exportObject) map[string]string {
+	refs := map[string]string{}
+	used := map[string]bool{}
+	assign := func(binding bool) {
+		group := []*exportObject{}
+		for _, o := range objects {
+			if o.typ.binding != binding {
+				continue
+			}
+			if binding {
+				target := o.d.Get("target").(string)
+				if ref, ok := refs[target]; ok {
+					// Name of the resource the binding targets
+					target = strings.Split(ref, ".")[1]
+				}
+				o.label = fmt.Sprintf("%s_%d", target, o.d.Get("order").(int))
+			}
+			o.label = exportSanitizeLabel(o.label, o.typ.resource)
+			group = append(group, o)
+		}
+		sort.SliceStable(group, func(i, j int) bool {
+			if group[i].typ.resource != group[j].typ.resource {
+				return group[i].typ.resource < group[j].typ.resource
+			}
+			if group[i].label != group[j].label {
+				return group[i].label < group[j].label
+			}
+			return group[i].id < group[j].id
+		})
+		for _, o := range group {
+			label := o.label
+			for n := 2; used[o.typ.resource+"."+label]; n++ {
+				label = fmt.Sprintf("%s_%d", o.label, n)
+			}
+			o.label = label
+			used[o.address()] = true
+			refs[o.id] = o.address() + ".id"
+			if v, ok := o.d.Get("uuid").(string); ok && v != "" && v != o.id {
+				refs[v] = o.address() + ".uuid"
+			}
+			if strings.HasPrefix(o.typ.resource, "authentik_provider_") {
+				refs["provider:"+o.id] = o.address() + ".id"
+			}
+			if o.typ.resource == "authentik_user" {
+				refs["user:"+o.id] = o.address() + ".id"
+			}
+		}
+	}
+	assign(false)
+	assign(true)
+	sort.SliceStable(objects, func(i, j int) bool {
+		if objects[i].typ.resource != objects[j].typ.resource {
+			return objects[i].typ.resource < objects[j].typ.resource
+		}
+		return objects[i].label < objects[j].label
+	})
+	return refs
+}
+
+// exportSanitizeLabel Convert a name to a valid resource name, like `default-authentication-flow`
+func exportSanitizeLabel(label string, resource string) string {
+	b := strings.Builder{}
+	for _, r := range strings.ToLower(label) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') || r == '_' || r == '-' {
+			b.WriteRune(r)
+		} else {
+			b.WriteRune('_')
+		}
+	}
+	l := strings.Trim(b.String(), "_-")
+	if l == "" {
+		l = strings.TrimPrefix(resource, "authentik_")
+	}
+	if !hclsyntax.ValidIdentifier(l) {
+		l = "_" + l
+	}
+	return l
+}
+
+// exportAttributes Write the attributes of an object which are set in its configuration. Values
+// which equal the default, computed attributes and sensitive attributes are left out. Required
+// sensitive attributes reference a sensitive variable, so that the configuration is still valid.
+func exportAttributes(body *hclwrite.Body, variables *hclwrite.Body, s map[string]*schema.Schema, o *exportObject, refs map[string]string) {
+	for _, k := range sortedKeys(s) {
+		ks := s[k]
+		if (ks.Computed && !ks.Optional && !ks.Required) || ks.Deprecated != "" {
+			continue
+		}
+		if offsetInSlice(k, exportSkipAttributes[o.typ.resource]) != -1 {
+			continue
+		}
+		v := o.d.Get(k)
+		if set, ok := v.(*schema.Set); ok {
+			v = set.List()
+			sort.Slice(v, func(i, j int) bool {
+				return fmt.Sprint(v.([]interface{})[i]) < fmt.Sprint(v.([]interface{})[j])
+			})
+		}
+		if !ks.Required && exportIsDefault(ks, v) {
+			continue
+		}
+		if ks.Sensitive && ks.Required {
+			body.SetAttributeRaw(k, exportVariable(variables, k, o))
+			continue
+		}
+		if ks.Sensitive {
+			body.AppendUnstructuredTokens(hclwrite.Tokens{{
+				Type:  hclsyntax.TokenComment,
+				Bytes: []byte(fmt.Sprintf("# %s is sensitive and was not exported\n", k)),
+			}})
+			continue
+		}
+		if tokens, ok := exportJSON(ks, v); ok {
+			body.SetAttributeRaw(k, tokens)
+			continue
+		}
+		body.SetAttributeRaw(k, exportValue(k, v, o, refs))
+	}
+}
+
+// exportVariable Declare a sensitive variable for a required sensitive attribute, which isn't returned by
+// the API, and return the reference to it
+func exportVariable(variables *hclwrite.Body, key string, o *exportObject) hclwrite.Tokens {
+	name := exportSanitizeLabel(fmt.Sprintf("%s_%s_%s", strings.TrimPrefix(o.typ.resource, "authentik_"), o.label, key), "")
+	if len(variables.Blocks()) > 0 {
+		variables.AppendNewline()
+	}
+	block := variables.AppendNewBlock("variable", []string{name}).Body()
+	block.SetAttributeValue("description", cty.StringVal(fmt.Sprintf("%s of %s", key, o.address())))
+	block.SetAttributeTraversal("type", hcl.Traversal{hcl.TraverseRoot{Name: "string"}})
+	block.SetAttributeValue("sensitive", cty.True)
+	return hclwrite.TokensForTraversal(hcl.Traversal{
+		hcl.TraverseRoot{Name: "var"},
+		hcl.TraverseAttr{Name: name},
+	})
+}
+
+// exportIsDefault Check if a value doesn't need to be configured, as it's the default or empty
+func exportIsDefault(s *schema.Schema, v interface{}) bool {
+	if s.Default != nil {
+		// Empty strings are attributes which aren't returned by the API, like `attributes_mode`
+		return fmt.Sprint(s.Default) == fmt.Sprint(v) || v == ""
+	}
+	switch vv := v.(type) {
+	case []interface{}:
+		return len(vv) == 0
+	case map[string]interface{}:
+		return len(vv) == 0
+	case nil:
+		return true
+	}
+	return reflect.ValueOf(v).IsZero()
+}
+
+// exportJSON Write JSON attributes with `jsonencode()`, so they're readable
+func exportJSON(s *schema.Schema, v interface{}) (hclwrite.Tokens, bool) {
+	raw, ok := v.(string)
+	if !ok || s.DiffSuppressFunc == nil || reflect.ValueOf(s.DiffSuppressFunc).Pointer() != reflect.ValueOf(diffSuppressJSON).Pointer() {
+		return nil, false
+	}
+	ty, err := ctyjson.ImpliedType([]byte(raw))
+	if err != nil {
+		return nil, false
+	}
+	val, err := ctyjson.Unmarshal([]byte(raw), ty)
+	if err != nil {
+		return nil, false
+	}
+	return hclwrite.TokensForFunctionCall("jsonencode", hclwrite.TokensForValue(val)), true
+}
+
+// exportValue Write a value, with references to other exported objects where possible
+func exportValue(key string, v interface{}, o *exportObject, refs map[string]string) hclwrite.Tokens {
+	switch vv := v.(type) {
+	case []interface{}:
+		elems := make([]hclwrite.Tokens, len(vv))
+		for i, e := range vv {
+			elems[i] = exportValue(key, e, o, refs)
+		}
+		return hclwrite.TokensForTuple(elems)
+	case map[string]interface{}:
+		m := make(map[string]cty.Value, len(vv))
+		for mk, mv := range vv {
+			m[mk] = cty.StringVal(fmt.Sprint(mv))
+		}
+		return hclwrite.TokensForValue(cty.MapVal(m))
+	case string:
+		if ref, ok := refs[vv]; ok && exportUUID.MatchString(vv) && vv != o.id && !strings.HasPrefix(ref, o.address()+".") {
+			return exportReference(ref)
+		}
+		return hclwrite.TokensForValue(cty.StringVal(vv))
+	case int:
+		if kind, ok := exportIntReferences[key]; ok {
+			if ref, ok := refs[kind+":"+strconv.Itoa(vv)]; ok {
+				return exportReference(ref)
+			}
+		}
+		return hclwrite.TokensForValue(cty.NumberIntVal(int64(vv)))
+	case float64:
+		return hclwrite.TokensForValue(cty.NumberFloatVal(vv))
+	case bool:
+		return hclwrite.TokensForValue(cty.BoolVal(vv))
+	}
+	return hclwrite.TokensForValue(cty.StringVal(fmt.Sprint(v)))
+}
+
+// exportReference Tokens of a reference like `authentik_flow.default.uuid`
+func exportReference(ref string) hclwrite.Tokens {
+	parts := strings.Split(ref, ".")
+	traversal := hcl.Traversal{hcl.TraverseRoot{Name: parts[0]}}
+	for _, p := range parts[1:] {
+		traversal = append(traversal, hcl.TraverseAttr{Name: p})
+	}
+	return hclwrite.TokensForTraversal(traversal)
+}
@@ -0,0 +1,90 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/hcl/v2/hclparse"
+	"github.com/stretchr/testify/assert"
+	"goauthentik.io/terraform-provider-authentik/internal/fakeauthentik"
+)
+
+func testExport(t *testing.T, opts ExportOptions) map[string]string {
+	p := testProviderWithConfig(t, map[string]interface{}{})
+	opts.Dir = t.TempDir()
+	assert.NoError(t, export(context.Background(), p, opts))
+	files := map[string]string{}
+	entries, err := os.ReadDir(opts.Dir)
+	assert.NoError(t, err)
+	parser := hclparse.NewParser()
+	for _, e := range entries {
+		b, err := os.ReadFile(filepath.Join(opts.Dir, e.Name()))
+		assert.NoError(t, err)
+		_, diags := parser.ParseHCL(b, e.Name())
+		assert.False(t, diags.HasErrors(), "%s: %s", e.Name(), diags.Error())
+		files[e.Name()] = string(b)
+	}
+	return files
+}
+
+func Test_export(t *testing.T) {
+	flow := testServer.Add("/flows/instances/", fakeauthentik.Object{"name": "Export Flow", "slug": "export-flow", "title": "Export", "designation": "authentication"})
+	stage := testServer.Add("/stages/dummy/", fakeauthentik.Object{"name": "Export Stage"})
+	binding := testServer.Add("/flows/bindings/", fakeauthentik.Object{"target": flow["pk"], "stage": stage["pk"], "order": 10, "policy_engine_mode": "any", "invalid_response_action": "retry"})
+	group := testServer.Add("/core/groups/", fakeauthentik.Object{"name": "export group", "attributes": map[string]interface{}{"foo": "bar"}})
+	captcha := testServer.Add("/stages/captcha/", fakeauthentik.Object{"name": "Export Captcha", "public_key": "public"})
+	defer func() {
+		testServer.Remove("/stages/captcha/", captcha["pk"].(string))
+		testServer.Remove("/core/groups/", group["pk"].(string))
+		testServer.Remove("/flows/bindings/", binding["pk"].(string))
+		testServer.Remove("/stages/dummy/", stage["pk"].(string))
+		testServer.Remove("/flows/instances/", "export-flow")
+	}()
+
+	log := &bytes.Buffer{}
+	files := testExport(t, ExportOptions{Log: log})
+	assert.Contains(t, files["versions.tf"], `source = "goauthentik/authentik"`)
+
+	assert.Contains(t, files["authentik_flow.tf"], `resource "authentik_flow" "export-flow" {`)
+	assert.Contains(t, files["authentik_stage_dummy.tf"], `resource "authentik_stage_dummy" "export_stage" {`)
+	assert.Contains(t, files["authentik_group.tf"], `attributes = jsonencode({`)
+	// Bindings are named after their target, and reference other exported objects
+	assert.Contains(t, files["authentik_flow_stage_binding.tf"], `resource "authentik_flow_stage_binding" "export-flow_10" {`)
+	assert.Regexp(t, `stage\s+= authentik_stage_dummy.export_stage.id\n`, files["authentik_flow_stage_binding.tf"])
+	assert.Regexp(t, `target\s+= authentik_flow.export-flow.uuid\n`, files["authentik_flow_stage_binding.tf"])
+	assert.Contains(t, files["imports.tf"], "to = authentik_flow_stage_binding.export-flow_10\n  id = \""+binding["pk"].(string)+"\"")
+	assert.Contains(t, files["imports.tf"], "to = authentik_flow.export-flow\n  id = \"export-flow\"")
+
+	// Required sensitive attributes reference a sensitive variable
+	assert.Regexp(t, `private_key\s+= var.stage_captcha_export_captcha_private_key\n`, files["authentik_stage_captcha.tf"])
+	assert.Contains(t, files["variables.tf"], `variable "stage_captcha_export_captcha_private_key" {`)
+	assert.Regexp(t, `sensitive\s+= true\n`, files["variables.tf"])
+
+	// Managed objects are skipped unless requested
+	assert.NotContains(t, files["authentik_property_mapping_saml.tf"], "authentik default SAML Mapping")
+	assert.Contains(t, log.String(), "Skipping managed authentik_property_mapping_saml")
+	files = testExport(t, ExportOptions{IncludeManaged: true})
+	assert.Contains(t, files["authentik_property_mapping_saml.tf"], `resource "authentik_property_mapping_saml" "authentik_default_saml_mapping__email" {`)
+}
+
+func Test_export_Deterministic(t *testing.T) {
+	for _, name := range []string{"Export Duplicate", "export duplicate"} {
+		stage := testServer.Add("/stages/dummy/", fakeauthentik.Object{"name": name})
+		defer testServer.Remove("/stages/dummy/", stage["pk"].(string))
+	}
+	first := testExport(t, ExportOptions{})
+	assert.Equal(t, first, testExport(t, ExportOptions{}))
+	// Names which are the same after sanitizing are made unique
+	assert.Contains(t, first["authentik_stage_dummy.tf"], `"export_duplicate" {`)
+	assert.Contains(t, first["authentik_stage_dummy.tf"], `"export_duplicate_2" {`)
+}
+
+func Test_exportSanitizeLabel(t *testing.T) {
+	assert.Equal(t, "default-authentication-flow", exportSanitizeLabel("default-authentication-flow", "authentik_flow"))
+	assert.Equal(t, "authentik_admins", exportSanitizeLabel("authentik Admins", "authentik_group"))
+	assert.Equal(t, "_2fa", exportSanitizeLabel("2FA", "authentik_stage_dummy"))
+	assert.Equal(t, "stage_dummy", exportSanitizeLabel("🙂", "authentik_stage_dummy"))
+}
@@ -0,0 +1,119 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// importPage A page of objects returned by a list endpoint
+type importPage struct {
+	res interface{}
+	hr  *http.Response
+	err error
+}
+
+// importList Wrap the response of a list request, like `importList(req.Page(page).Execute())`
+func importList[T any](res T, hr *http.Response, err error) importPage {
+	return importPage{res, hr, err}
+}
+
+// importListFunc Fetch a page of the objects matching the value of a natural key
+type importListFunc func(ctx context.Context, c *APIClient, value string, page int32) importPage
+
+// importStateByKey Importer which accepts the ID of an object, or a natural key like `name:<name>`.
+// Natural keys are looked up with the list endpoint of the resource, and have to match exactly one
+// object. idField is the JSON field of the listed objects that is used as the resource ID.
+func importStateByKey(idField string, lookups map[string]importListFunc) *schema.ResourceImporter {
+	return &schema.ResourceImporter{
+		StateContext: func(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+			key, value, ok := strings.Cut(d.Id(), ":")
+			if !ok {
+				return []*schema.ResourceData{d}, nil
+			}
+			list, ok := lookups[key]
+			if !ok {
+				return []*schema.ResourceData{d}, nil
+			}
+			ids, err := importLookup(ctx, m.(*APIClient), idField, list, value)
+			if err != nil {
+				return nil, err
+			}
+			switch len(ids) {
+			case 0:
+				return nil, fmt.Errorf("no object found with %s %q", key, value)
+			case 1:
+				d.SetId(ids[0])
+				return []*schema.ResourceData{d}, nil
+			default:
+				return nil, fmt.Errorf("%d objects found with %s %q (IDs %s), import by ID instead", len(ids), key, value, strings.Join(ids, ", "))
+			}
+		},
+	}
+}
+
+// importLookup Collect the IDs of all objects returned by list, across all pages
+func importLookup(ctx context.Context, c *APIClient, idField string, list importListFunc, value string) ([]string, error) {
+	ids := []string{}
+	for page := int32(1); true; page++ {
+		results, next, err := list(ctx, c, value, page).decode()
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range results {
+			ids = append(ids, fmt.Sprint(r[idField]))
+		}
+		if !next {
+			break
+		}
+	}
+	return ids, nil
+}
+
+// decode Get the objects of the page as generic JSON objects, and whether there is a next page.
+// Numbers are kept as json.Number, so that IDs are formatted the same as in the API.
+func (p importPage) decode() ([]map[string]interface{}, bool, error) {
+	if p.err != nil {
+		if p.hr == nil {
+			return nil, false, p.err
+		}
+		return nil, false, fmt.Errorf("failed to list objects: %s", httpToDiag(nil, p.hr, p.err)[0].Summary)
+	}
+	b, err := json.Marshal(p.res)
+	if err != nil {
+		return nil, false, err
+	}
+	var res struct {
+		Pagination struct {
+			Next json.Number `json:"next"`
+		} `json:"pagination"`
+		Results []map[string]interface{} `json:"results"`
+	}
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	err = dec.Decode(&res)
+	if err != nil {
+		return nil, false, err
+	}
+	next, _ := res.Pagination.Next.Float64()
+	return res.Results, next != 0, nil
+}
+
+// parseBindingImportKey Parse the value of a `target:<uuid>/order:<n>` import ID
+func parseBindingImportKey(value string) (string, int32, error) {
+	target, order, ok := strings.Cut(value, "/order:")
+	if !ok || target == "" {
+		return "", 0, fmt.Errorf("invalid import ID, expected `target:<uuid>/order:<n>`")
+	}
+	o, err := strconv.ParseInt(order, 10, 32)
+	if err != nil {
+		return "", 0, fmt.Errorf("invalid order %q in import ID: %w", order, err)
+	}
+	return target, int32(o), nil
+}
@@ -0,0 +1,98 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"goauthentik.io/terraform-provider-authentik/internal/fakeauthentik"
+)
+
+func testImport(t *testing.T, resource string, id string) (string, error) {
+	p := testProviderWithConfig(t, map[string]interface{}{})
+	r := p.ResourcesMap[resource]
+	d := r.TestResourceData()
+	d.SetId(id)
+	res, err := r.Importer.StateContext(context.Background(), d, p.Meta())
+	if err != nil {
+		return "", err
+	}
+	assert.Len(t, res, 1)
+	return res[0].Id(), nil
+}
+
+func Test_importStateByKey(t *testing.T) {
+	flow := testServer.Add("/flows/instances/", fakeauthentik.Object{"name": "import-flow", "slug": "import-flow", "title": "import", "designation": "authentication"})
+	stage := testServer.Add("/stages/dummy/", fakeauthentik.Object{"name": "import-stage"})
+	user := testServer.Add("/core/users/", fakeauthentik.Object{"username": "import-user", "name": "import-user"})
+	provider := testServer.Add("/providers/oauth2/", fakeauthentik.Object{"name": "import-provider"})
+	binding := testServer.Add("/flows/bindings/", fakeauthentik.Object{"target": flow["pk"], "stage": stage["pk"], "order": 10})
+	group := testServer.Add("/core/groups/", fakeauthentik.Object{"name": "import-group"})
+	tenant := testServer.Add("/core/tenants/", fakeauthentik.Object{"domain": "import.example.com"})
+	defer func() {
+		testServer.Remove("/core/tenants/", tenant["tenant_uuid"].(string))
+		testServer.Remove("/core/groups/", group["pk"].(string))
+		testServer.Remove("/flows/bindings/", binding["pk"].(string))
+		testServer.Remove("/providers/oauth2/", fmt.Sprint(provider["pk"]))
+		testServer.Remove("/core/users/", fmt.Sprint(user["pk"]))
+		testServer.Remove("/stages/dummy/", stage["pk"].(string))
+		testServer.Remove("/flows/instances/", "import-flow")
+	}()
+
+	for _, tc := range []struct {
+		resource string
+		id       string
+		expected string
+	}{
+		{"authentik_flow", "slug:import-flow", "import-flow"},
+		{"authentik_stage_dummy", "name:import-stage", stage["pk"].(string)},
+		{"authentik_user", "username:import-user", fmt.Sprint(user["pk"])},
+		{"authentik_provider_oauth2", "name:import-provider", fmt.Sprint(provider["pk"])},
+		{"authentik_group", "name:import-group", group["pk"].(string)},
+		{"authentik_tenant", "domain:import.example.com", tenant["tenant_uuid"].(string)},
+		{"authentik_flow_stage_binding", fmt.Sprintf("target:%s/order:10", flow["pk"]), binding["pk"].(string)},
+		// IDs are passed through
+		{"authentik_stage_dummy", stage["pk"].(string), stage["pk"].(string)},
+		{"authentik_user", "5", "5"},
+	} {
+		id, err := testImport(t, tc.resource, tc.id)
+		assert.NoError(t, err, tc.id)
+		assert.Equal(t, tc.expected, id, tc.id)
+	}
+}
+
+func Test_importStateByKey_Errors(t *testing.T) {
+	flow := testServer.Add("/flows/instances/", fakeauthentik.Object{"name": "import-errors", "slug": "import-errors", "title": "import", "designation": "authentication"})
+	var bindings []fakeauthentik.Object
+	for _, name := range []string{"import-errors-a", "import-errors-b"} {
+		stage := testServer.Add("/stages/dummy/", fakeauthentik.Object{"name": name})
+		bindings = append(bindings, testServer.Add("/flows/bindings/", fakeauthentik.Object{"target": flow["pk"], "stage": stage["pk"], "order": 0}))
+		defer testServer.Remove("/stages/dummy/", stage["pk"].(string))
+	}
+	defer func() {
+		for _, b := range bindings {
+			testServer.Remove("/flows/bindings/", b["pk"].(string))
+		}
+		testServer.Remove("/flows/instances/", "import-errors")
+	}()
+
+	_, err := testImport(t, "authentik_stage_dummy", "name:import-errors-missing")
+	assert.EqualError(t, err, `no object found with name "import-errors-missing"`)
+
+	_, err = testImport(t, "authentik_flow_stage_binding", fmt.Sprintf("target:%s/order:0", flow["pk"]))
+	assert.ErrorContains(t, err, `2 objects found with target`)
+	assert.ErrorContains(t, err, bindings[0]["pk"].(string))
+
+	_, err = testImport(t, "authentik_policy_binding", "target:foo/order:bar")
+	assert.ErrorContains(t, err, `invalid order "bar"`)
+	_, err = testImport(t, "authentik_policy_binding", "target:foo")
+	assert.ErrorContains(t, err, "expected `target:<uuid>/order:<n>`")
+}
+
+func Test_parseBindingImportKey(t *testing.T) {
+	target, order, err := parseBindingImportKey("a0b1c2d3-e4f5-4a6b-8c7d-8e9f0a1b2c3d/order:20")
+	assert.NoError(t, err)
+	assert.Equal(t, "a0b1c2d3-e4f5-4a6b-8c7d-8e9f0a1b2c3d", target)
+	assert.Equal(t, int32(20), order)
+}
@@ -0,0 +1,153 @@
+package provider
+
+import (
+	"container/list"
+	"context"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// limitTransport Transport that limits the number of concurrent requests and the rate at which
+// requests are sent. Requests are queued in the order they were made. A single instance is used by
+// all resources and data sources of a provider, as they share the HTTP client of the APIClient.
+type limitTransport struct {
+	inner         http.RoundTripper
+	maxConcurrent int
+	interval      time.Duration
+
+	mu      sync.Mutex
+	active  int
+	waiters list.List
+	next    time.Time
+}
+
+// NewLimitTransport Get a HTTP transport which allows at most maxConcurrent requests at the same time,
+// and at most requestsPerSecond requests per second. Either limit is disabled when set to 0.
+func NewLimitTransport(inner http.RoundTripper, maxConcurrent int, requestsPerSecond float64) *limitTransport {
+	lt := &limitTransport{
+		inner:         inner,
+		maxConcurrent: maxConcurrent,
+	}
+	if requestsPerSecond > 0 {
+		lt.interval = time.Duration(float64(time.Second) / requestsPerSecond)
+	}
+	return lt
+}
+
+// acquire Wait for a free request slot, waiting requests are served first-in first-out
+func (lt *limitTransport) acquire(ctx context.Context) error {
+	if lt.maxConcurrent < 1 {
+		return nil
+	}
+	lt.mu.Lock()
+	if lt.active < lt.maxConcurrent && lt.waiters.Len() == 0 {
+		lt.active += 1
+		lt.mu.Unlock()
+		return nil
+	}
+	ready := make(chan struct{})
+	elem := lt.waiters.PushBack(ready)
+	lt.mu.Unlock()
+
+	select {
+	case <-ready:
+		return nil
+	case <-ctx.Done():
+		lt.mu.Lock()
+		select {
+		case <-ready:
+			// The slot was handed to us while cancelling, pass it on
+			lt.mu.Unlock()
+			lt.release()
+		default:
+			lt.waiters.Remove(elem)
+			lt.mu.Unlock()
+		}
+		return ctx.Err()
+	}
+}
+
+// release Hand the request slot to the next waiting request, or free it
+func (lt *limitTransport) release() {
+	if lt.maxConcurrent < 1 {
+		return
+	}
+	lt.mu.Lock()
+	defer lt.mu.Unlock()
+	if front := lt.waiters.Front(); front != nil {
+		lt.waiters.Remove(front)
+		close(front.Value.(chan struct{}))
+		return
+	}
+	lt.active -= 1
+}
+
+// reserve Reserve the next point in time a request may be sent at, and return how long to wait for it
+func (lt *limitTransport) reserve() time.Duration {
+	if lt.interval <= 0 {
+		return 0
+	}
+	lt.mu.Lock()
+	defer lt.mu.Unlock()
+	now := time.Now()
+	if lt.next.Before(now) {
+		lt.next = now
+	}
+	wait := lt.next.Sub(now)
+	lt.next = lt.next.Add(lt.interval)
+	return wait
+}
+
+// RoundTrip HTTP Transport
+func (lt *limitTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	ctx := r.Context()
+	start := time.Now()
+	err := lt.acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if wait := lt.reserve(); wait > 0 {
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			lt.release()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+	if queued := time.Since(start); queued >= time.Millisecond {
+		tflog.Debug(ctx, "authentik: API request was queued", map[string]interface{}{
+			"http_method":    r.Method,
+			"http_path":      r.URL.Path,
+			"queue_wait_ms":  queued.Milliseconds(),
+			"max_concurrent": lt.maxConcurrent,
+		})
+	}
+
+	res, err := lt.inner.RoundTrip(r)
+	if err != nil || res.Body == nil {
+		lt.release()
+		return res, err
+	}
+	// The slot is held until the response has been read
+	res.Body = &releasingBody{ReadCloser: res.Body, release: lt.release}
+	return res, nil
+}
+
+// releasingBody Response body which releases the request slot once it's closed
+type releasingBody struct {
+	io.ReadCloser
+	release func()
+	once    sync.Once
+}
+
+func (rb *releasingBody) Close() error {
+	err := rb.ReadCloser.Close()
+	rb.once.Do(rb.release)
+	return err
+}
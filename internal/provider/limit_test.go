@@ -0,0 +1,159 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+	"github.com/stretchr/testify/assert"
+)
+
+// blockingTransport Transport which records the order of requests and blocks until released
+type blockingTransport struct {
+	mu      sync.Mutex
+	order   []string
+	active  int
+	peak    int
+	release chan struct{}
+}
+
+func (bt *blockingTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	bt.mu.Lock()
+	bt.order = append(bt.order, r.URL.Path)
+	bt.active += 1
+	if bt.active > bt.peak {
+		bt.peak = bt.active
+	}
+	bt.mu.Unlock()
+	if bt.release != nil {
+		<-bt.release
+	}
+	bt.mu.Lock()
+	bt.active -= 1
+	bt.mu.Unlock()
+	return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody, Request: r}, nil
+}
+
+func testLimitRequest(t *testing.T, ctx context.Context, transport http.RoundTripper, path string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "http://authentik.invalid"+path, nil)
+	assert.NoError(t, err)
+	res, err := transport.RoundTrip(req)
+	if err != nil {
+		return err
+	}
+	return res.Body.Close()
+}
+
+func testLimitConcurrent(t *testing.T, maxConcurrent int, paths []string) *blockingTransport {
+	inner := &blockingTransport{release: make(chan struct{})}
+	lt := NewLimitTransport(inner, maxConcurrent, 0)
+
+	var wg sync.WaitGroup
+	for idx, path := range paths {
+		wg.Add(1)
+		go func(path string) {
+			defer wg.Done()
+			assert.NoError(t, testLimitRequest(t, context.Background(), lt, path))
+		}(path)
+		// Wait until the request has been started or queued, so the order is deterministic
+		assert.Eventually(t, func() bool {
+			lt.mu.Lock()
+			defer lt.mu.Unlock()
+			return lt.active+lt.waiters.Len() == idx+1
+		}, time.Second, time.Millisecond)
+	}
+	for range paths {
+		inner.release <- struct{}{}
+	}
+	wg.Wait()
+
+	lt.mu.Lock()
+	defer lt.mu.Unlock()
+	assert.Equal(t, 0, lt.active)
+	assert.Equal(t, 0, lt.waiters.Len())
+	return inner
+}
+
+func Test_limitTransport_Concurrency(t *testing.T) {
+	inner := testLimitConcurrent(t, 2, []string{"/a", "/b", "/c", "/d", "/e"})
+	assert.Equal(t, 2, inner.peak)
+	assert.Len(t, inner.order, 5)
+}
+
+func Test_limitTransport_Order(t *testing.T) {
+	paths := []string{"/a", "/b", "/c", "/d", "/e"}
+	inner := testLimitConcurrent(t, 1, paths)
+	assert.Equal(t, 1, inner.peak)
+	// Queued requests are sent in the order they were made
+	assert.Equal(t, paths, inner.order)
+}
+
+func Test_limitTransport_Cancel(t *testing.T) {
+	inner := &blockingTransport{release: make(chan struct{})}
+	lt := NewLimitTransport(inner, 1, 0)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		assert.NoError(t, testLimitRequest(t, context.Background(), lt, "/a"))
+	}()
+	assert.Eventually(t, func() bool {
+		lt.mu.Lock()
+		defer lt.mu.Unlock()
+		return lt.active == 1
+	}, time.Second, time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	err := testLimitRequest(t, ctx, lt, "/b")
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	lt.mu.Lock()
+	assert.Equal(t, 0, lt.waiters.Len())
+	lt.mu.Unlock()
+
+	inner.release <- struct{}{}
+	<-done
+	assert.Equal(t, []string{"/a"}, inner.order)
+	lt.mu.Lock()
+	defer lt.mu.Unlock()
+	assert.Equal(t, 0, lt.active)
+}
+
+func Test_limitTransport_Rate(t *testing.T) {
+	inner := &blockingTransport{}
+	lt := NewLimitTransport(inner, 0, 20)
+
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+	start := time.Now()
+	for _, path := range []string{"/a", "/b", "/c", "/d"} {
+		assert.NoError(t, testLimitRequest(t, ctx, lt, path))
+	}
+	// The first request is sent immediately, every further one 50ms after the previous one
+	assert.GreaterOrEqual(t, time.Since(start), 150*time.Millisecond)
+
+	entries, err := tflogtest.MultilineJSONDecode(&output)
+	assert.NoError(t, err)
+	assert.Len(t, entries, 3)
+	for _, entry := range entries {
+		assert.Equal(t, "authentik: API request was queued", entry["@message"])
+		assert.Contains(t, entry, "queue_wait_ms")
+	}
+}
+
+func Test_limitTransport_Unlimited(t *testing.T) {
+	inner := &blockingTransport{}
+	lt := NewLimitTransport(inner, 0, 0)
+
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+	for _, path := range []string{"/a", "/b", "/c"} {
+		assert.NoError(t, testLimitRequest(t, ctx, lt, path))
+	}
+	assert.Equal(t, []string{"/a", "/b", "/c"}, inner.order)
+	assert.Empty(t, output.String())
+}
@@ -0,0 +1,69 @@
+package provider
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// loggingTransport Transport which logs all requests and responses with tflog, which are shown
+// with `TF_LOG_PROVIDER=DEBUG`. Sensitive headers and fields are redacted, and bodies are only
+// logged when enabled.
+type loggingTransport struct {
+	inner     http.RoundTripper
+	logBodies bool
+}
+
+// NewLoggingTransport Get a HTTP transport that logs all requests and responses
+func NewLoggingTransport(inner http.RoundTripper, logBodies bool) *loggingTransport {
+	return &loggingTransport{inner, logBodies}
+}
+
+// RoundTrip HTTP Transport
+func (lt *loggingTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	ctx := r.Context()
+	fields := map[string]interface{}{
+		"http_method":          r.Method,
+		"http_path":            r.URL.Path,
+		"http_query":           r.URL.RawQuery,
+		"http_request_headers": redactHeaders(r.Header),
+	}
+	if lt.logBodies && r.Body != nil {
+		b, err := io.ReadAll(r.Body)
+		if err != nil {
+			return nil, err
+		}
+		_ = r.Body.Close()
+		r = r.Clone(ctx)
+		r.Body = io.NopCloser(bytes.NewReader(b))
+		fields["http_request_body"] = redactBody(r.Header.Get("Content-Type"), b)
+	}
+	tflog.Debug(ctx, "authentik: sending API request", fields)
+
+	start := time.Now()
+	res, err := lt.inner.RoundTrip(r)
+	fields["http_duration_ms"] = time.Since(start).Milliseconds()
+	delete(fields, "http_request_headers")
+	delete(fields, "http_request_body")
+	if err != nil {
+		fields["error"] = err.Error()
+		tflog.Debug(ctx, "authentik: API request failed", fields)
+		return res, err
+	}
+	fields["http_status_code"] = res.StatusCode
+	fields["http_response_headers"] = redactHeaders(res.Header)
+	if lt.logBodies && res.Body != nil {
+		b, err := io.ReadAll(res.Body)
+		_ = res.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		res.Body = io.NopCloser(bytes.NewReader(b))
+		fields["http_response_body"] = redactBody(res.Header.Get("Content-Type"), b)
+	}
+	tflog.Debug(ctx, "authentik: received API response", fields)
+	return res, nil
+}
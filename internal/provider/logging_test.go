@@ -0,0 +1,84 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+	"github.com/stretchr/testify/assert"
+)
+
+func testLoggingRequest(t *testing.T, logBodies bool) (string, []map[string]interface{}) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"name": "foo", "client_secret": "server-secret"}`))
+	}))
+	defer srv.Close()
+
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, srv.URL+"/api/v3/providers/oauth2/", strings.NewReader(`{"name": "foo", "client_secret": "client-secret"}`))
+	assert.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer token")
+	req.Header.Set("Content-Type", "application/json")
+	res, err := NewLoggingTransport(http.DefaultTransport, logBodies).RoundTrip(req)
+	assert.NoError(t, err)
+	body, err := io.ReadAll(res.Body)
+	assert.NoError(t, err)
+	// The response body can still be read after being logged
+	assert.Contains(t, string(body), "server-secret")
+
+	entries, err := tflogtest.MultilineJSONDecode(&output)
+	assert.NoError(t, err)
+	return output.String(), entries
+}
+
+func Test_loggingTransport(t *testing.T) {
+	raw, entries := testLoggingRequest(t, false)
+	assert.Len(t, entries, 2)
+	assert.Equal(t, "POST", entries[0]["http_method"])
+	assert.Equal(t, "/api/v3/providers/oauth2/", entries[0]["http_path"])
+	assert.Equal(t, float64(http.StatusCreated), entries[1]["http_status_code"])
+	assert.Contains(t, entries[1], "http_duration_ms")
+	assert.NotContains(t, entries[0], "http_request_body")
+	assert.NotContains(t, entries[1], "http_response_body")
+	assert.NotContains(t, raw, "Bearer token")
+}
+
+func Test_loggingTransport_Bodies(t *testing.T) {
+	raw, entries := testLoggingRequest(t, true)
+	assert.Len(t, entries, 2)
+	assert.Equal(t, `{"client_secret":"REDACTED","name":"foo"}`, entries[0]["http_request_body"])
+	assert.Equal(t, `{"client_secret":"REDACTED","name":"foo"}`, entries[1]["http_response_body"])
+	assert.NotContains(t, raw, "client-secret")
+	assert.NotContains(t, raw, "server-secret")
+	assert.NotContains(t, raw, "Bearer token")
+}
+
+func Test_loggingTransport_TokenRequest(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"access_token": "issued-token", "expires_in": 300}`))
+	}))
+	defer srv.Close()
+
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+	client := &http.Client{Transport: NewLoggingTransport(http.DefaultTransport, true)}
+	token, _, err := clientCredentialsTokenFetcher(client, srv.URL+"/application/o/token/", "terraform", "svc", "app-password", "goauthentik.io/api")(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "issued-token", token)
+
+	entries, err := tflogtest.MultilineJSONDecode(bytes.NewReader(output.Bytes()))
+	assert.NoError(t, err)
+	assert.Contains(t, entries[0]["http_request_body"], "password=REDACTED")
+	assert.Contains(t, entries[0]["http_request_body"], "client_id=terraform")
+	assert.NotContains(t, output.String(), "app-password")
+	assert.NotContains(t, output.String(), "issued-token")
+}
@@ -0,0 +1,82 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// managedResources Resources of models which authentik can manage itself, like the default property
+// mappings or the embedded outpost. Their Read functions set the `managed` attribute.
+var managedResources = []string{
+	"authentik_certificate_key_pair",
+	"authentik_outpost",
+	"authentik_property_mapping_ldap",
+	"authentik_property_mapping_saml",
+	"authentik_property_mapping_scim",
+	"authentik_scope_mapping",
+	"authentik_source_ldap",
+	"authentik_source_oauth",
+	"authentik_source_plex",
+	"authentik_source_saml",
+	"authentik_token",
+}
+
+// withManagedProtection Add the `managed` and `force_destroy_managed` attributes to all resources of
+// managed models, and only remove managed objects from the state when they're destroyed
+func withManagedProtection(resources map[string]*schema.Resource) map[string]*schema.Resource {
+	for _, name := range managedResources {
+		r := resources[name]
+		r.Schema["managed"] = &schema.Schema{
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Set when the object is managed by authentik itself, for example by a blueprint. Managed objects are only removed from the state when they're destroyed, unless `force_destroy_managed` is set.",
+		}
+		r.Schema["force_destroy_managed"] = &schema.Schema{
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: "Delete the object when it's destroyed, even if it's managed by authentik.",
+		}
+		r.ReadContext = protectingRead(r.ReadContext)
+		r.DeleteContext = protectingDelete(r.DeleteContext)
+	}
+	return resources
+}
+
+// protectingRead Write `force_destroy_managed` to the state, as state written before it existed doesn't
+// have it, which would show up as a diff against its default
+func protectingRead(read schema.ReadContextFunc) schema.ReadContextFunc {
+	return func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		diags := read(ctx, d, m)
+		if d.Id() != "" {
+			setWrapper(d, "force_destroy_managed", d.Get("force_destroy_managed").(bool))
+		}
+		return diags
+	}
+}
+
+func protectingDelete(del schema.DeleteContextFunc) schema.DeleteContextFunc {
+	return func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		managed := d.Get("managed").(string)
+		if managed == "" || d.Get("force_destroy_managed").(bool) {
+			return del(ctx, d, m)
+		}
+		id := d.Id()
+		tflog.Warn(ctx, "authentik: not deleting managed object", map[string]interface{}{
+			"id":      id,
+			"managed": managed,
+		})
+		d.SetId("")
+		return diag.Diagnostics{
+			{
+				Severity: diag.Warning,
+				Summary:  "Managed object was not deleted",
+				Detail:   fmt.Sprintf("The object with ID %s is managed by authentik (%s), so it was only removed from the state. Set `force_destroy_managed` to delete it.", id, managed),
+			},
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"goauthentik.io/terraform-provider-authentik/internal/fakeauthentik"
+)
+
+func Test_managedProtection(t *testing.T) {
+	mapping := testServer.Add("/propertymappings/scope/", fakeauthentik.Object{
+		"name":       "managed-test",
+		"managed":    "goauthentik.io/test/managed",
+		"scope_name": "test",
+		"expression": "return {}",
+	})
+	pk := mapping["pk"].(string)
+	defer testServer.Remove("/propertymappings/scope/", pk)
+
+	p := testProviderWithConfig(t, map[string]interface{}{})
+	r := p.ResourcesMap["authentik_scope_mapping"]
+	d := r.Data(nil)
+	d.SetId(pk)
+	diags := r.ReadContext(context.Background(), d, p.Meta())
+	assert.False(t, diags.HasError(), diags)
+	assert.Equal(t, "goauthentik.io/test/managed", d.Get("managed"))
+
+	// Managed objects are only removed from the state
+	diags = r.DeleteContext(context.Background(), d, p.Meta())
+	assert.False(t, diags.HasError(), diags)
+	assert.Equal(t, diag.Warning, diags[0].Severity)
+	assert.Equal(t, "Managed object was not deleted", diags[0].Summary)
+	assert.Equal(t, "", d.Id())
+	_, ok := testServer.Get("/propertymappings/scope/", pk)
+	assert.True(t, ok)
+
+	// Unless deleting them is forced
+	d.SetId(pk)
+	assert.False(t, r.ReadContext(context.Background(), d, p.Meta()).HasError())
+	assert.NoError(t, d.Set("force_destroy_managed", true))
+	diags = r.DeleteContext(context.Background(), d, p.Meta())
+	assert.False(t, diags.HasError(), diags)
+	assert.Empty(t, diags)
+	_, ok = testServer.Get("/propertymappings/scope/", pk)
+	assert.False(t, ok)
+
+	// Objects which aren't managed are deleted
+	mapping = testServer.Add("/propertymappings/scope/", fakeauthentik.Object{"name": "unmanaged-test", "scope_name": "test", "expression": "return {}"})
+	pk = mapping["pk"].(string)
+	d = schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{})
+	d.SetId(pk)
+	assert.False(t, r.ReadContext(context.Background(), d, p.Meta()).HasError())
+	assert.Equal(t, "", d.Get("managed"))
+	diags = r.DeleteContext(context.Background(), d, p.Meta())
+	assert.Empty(t, diags)
+	_, ok = testServer.Get("/propertymappings/scope/", pk)
+	assert.False(t, ok)
+}
+
+func Test_withManagedProtection(t *testing.T) {
+	p := Provider("test", false)
+	for _, name := range managedResources {
+		r, ok := p.ResourcesMap[name]
+		assert.True(t, ok, name)
+		assert.Contains(t, r.Schema, "managed", name)
+		assert.Contains(t, r.Schema, "force_destroy_managed", name)
+	}
+}
+
+func Test_managedProtection_Upgrade(t *testing.T) {
+	mapping := testServer.Add("/propertymappings/scope/", fakeauthentik.Object{"name": "upgrade-test", "scope_name": "test", "expression": "return {}"})
+	pk := mapping["pk"].(string)
+	defer testServer.Remove("/propertymappings/scope/", pk)
+
+	p := testProviderWithConfig(t, map[string]interface{}{})
+	r := p.ResourcesMap["authentik_scope_mapping"]
+	// State written by a version without `force_destroy_managed`
+	d := r.Data(&terraform.InstanceState{ID: pk, Attributes: map[string]string{"id": pk}})
+	diags := r.ReadContext(context.Background(), d, p.Meta())
+	assert.False(t, diags.HasError(), diags)
+	assert.Equal(t, "false", d.State().Attributes["force_destroy_managed"])
+
+	// A configured value is kept
+	assert.NoError(t, d.Set("force_destroy_managed", true))
+	diags = r.ReadContext(context.Background(), d, p.Meta())
+	assert.False(t, diags.HasError(), diags)
+	assert.Equal(t, "true", d.State().Attributes["force_destroy_managed"])
+}
@@ -0,0 +1,112 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// ownershipAttribute Attribute which objects are stamped with when the provider's `ownership_key` is set
+const ownershipAttribute = "terraform/workspace"
+
+// ownedResources API paths of the resources whose objects have attributes, and can be stamped
+// with the provider's `ownership_key`
+var ownedResources = map[string]string{
+	"authentik_group":  "/core/groups/",
+	"authentik_tenant": "/core/tenants/",
+	"authentik_user":   "/core/users/",
+}
+
+// withOwnership Refuse to update or delete objects which are stamped with a different `ownership_key`.
+// Objects are stamped by attributesSchemaToModel.
+func withOwnership(resources map[string]*schema.Resource) map[string]*schema.Resource {
+	for name, path := range ownedResources {
+		r := resources[name]
+		path := path
+		update := r.UpdateContext
+		r.UpdateContext = func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+			if diags := checkOwnership(ctx, d, m.(*APIClient), path, "updated"); diags != nil {
+				return diags
+			}
+			return update(ctx, d, m)
+		}
+		del := r.DeleteContext
+		r.DeleteContext = func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+			if diags := checkOwnership(ctx, d, m.(*APIClient), path, "deleted"); diags != nil {
+				return diags
+			}
+			return del(ctx, d, m)
+		}
+	}
+	return resources
+}
+
+// checkOwnership Check that the object isn't stamped with another `ownership_key`. Objects without a
+// stamp can be changed by everyone, and are stamped by the next update.
+func checkOwnership(ctx context.Context, d *schema.ResourceData, c *APIClient, path string, action string) diag.Diagnostics {
+	if c.ownershipKey == "" {
+		return nil
+	}
+	raw, hr, err := c.apiRequest(ctx, http.MethodGet, path+d.Id(), nil, nil)
+	if hr != nil && hr.StatusCode == http.StatusNotFound {
+		// Update and Delete handle objects which don't exist anymore themselves
+		return nil
+	}
+	if err != nil {
+		return httpToDiag(d, hr, err)
+	}
+	obj, err := decodeJSONObject(raw)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	attr, _ := obj["attributes"].(map[string]interface{})
+	owner := foreignOwner(c, attr)
+	if owner == "" {
+		return nil
+	}
+	tflog.Warn(ctx, "authentik: object is owned by another workspace", map[string]interface{}{
+		"id":    d.Id(),
+		"owner": owner,
+	})
+	return diag.Diagnostics{
+		{
+			Severity: diag.Error,
+			Summary:  "Object is owned by another workspace",
+			Detail: fmt.Sprintf("The object with ID %s has the `%s` attribute %q, but the provider's `ownership_key` is %q, so it wasn't %s. "+
+				"Remove the resource from the state, or remove the attribute from the object to take it over.", d.Id(), ownershipAttribute, owner, c.ownershipKey, action),
+		},
+	}
+}
+
+// foreignOwner Get the `ownership_key` an object was stamped with, if it's not the provider's own key
+func foreignOwner(c *APIClient, attr map[string]interface{}) string {
+	if c.ownershipKey == "" {
+		return ""
+	}
+	owner, _ := attr[ownershipAttribute].(string)
+	if owner == c.ownershipKey {
+		return ""
+	}
+	return owner
+}
+
+// ownershipWarning Warn when an object that is read is stamped with another `ownership_key`, as it
+// can't be updated or deleted by this workspace
+func ownershipWarning(d *schema.ResourceData, c *APIClient, attr map[string]interface{}) diag.Diagnostics {
+	owner := foreignOwner(c, attr)
+	if owner == "" {
+		return nil
+	}
+	return diag.Diagnostics{
+		{
+			Severity: diag.Warning,
+			Summary:  "Object is owned by another workspace",
+			Detail: fmt.Sprintf("The object with ID %s has the `%s` attribute %q, but the provider's `ownership_key` is %q. "+
+				"It will not be updated or deleted by this workspace.", d.Id(), ownershipAttribute, owner, c.ownershipKey),
+		},
+	}
+}
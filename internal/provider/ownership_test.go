@@ -0,0 +1,102 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"goauthentik.io/terraform-provider-authentik/internal/fakeauthentik"
+)
+
+func Test_ownershipAttributes(t *testing.T) {
+	c := &APIClient{ownershipKey: "workspace-a"}
+	d := resourceGroup().TestResourceData()
+	assert.NoError(t, d.Set("attributes", `{"foo": "bar"}`))
+	attr, diags := attributesSchemaToModel(d, c)
+	assert.Nil(t, diags)
+	assert.Equal(t, map[string]interface{}{"foo": "bar", ownershipAttribute: "workspace-a"}, attr)
+
+	// The stamp is left out of the state
+	state, diags := attributesModelToSchema(d, c, map[string]interface{}{"foo": "bar", ownershipAttribute: "workspace-b"})
+	assert.Nil(t, diags)
+	assert.Equal(t, `{"foo":"bar"}`, state)
+}
+
+func Test_withOwnership(t *testing.T) {
+	p := testProviderWithConfig(t, map[string]interface{}{"ownership_key": "workspace-a"})
+	r := p.ResourcesMap["authentik_group"]
+
+	// Created objects are stamped
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{"name": "owned-group"})
+	diags := r.CreateContext(context.Background(), d, p.Meta())
+	assert.False(t, diags.HasError(), diags)
+	obj, _ := testServer.Get("/core/groups/", d.Id())
+	assert.Equal(t, "workspace-a", obj["attributes"].(map[string]interface{})[ownershipAttribute])
+	assert.Equal(t, "{}", d.Get("attributes"))
+	assert.Empty(t, r.DeleteContext(context.Background(), d, p.Meta()))
+
+	// Objects without a stamp are stamped when they're updated
+	obj = testServer.Add("/core/groups/", fakeauthentik.Object{"name": "unowned-group"})
+	d = schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{"name": "unowned-group"})
+	d.SetId(obj["pk"].(string))
+	diags = r.UpdateContext(context.Background(), d, p.Meta())
+	assert.False(t, diags.HasError(), diags)
+	obj, _ = testServer.Get("/core/groups/", d.Id())
+	assert.Equal(t, "workspace-a", obj["attributes"].(map[string]interface{})[ownershipAttribute])
+	assert.Empty(t, r.DeleteContext(context.Background(), d, p.Meta()))
+
+	// Objects of other workspaces are neither updated nor deleted
+	obj = testServer.Add("/core/groups/", fakeauthentik.Object{
+		"name":       "foreign-group",
+		"attributes": map[string]interface{}{ownershipAttribute: "workspace-b"},
+	})
+	pk := obj["pk"].(string)
+	defer testServer.Remove("/core/groups/", pk)
+	d = schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{"name": "renamed-group"})
+	d.SetId(pk)
+	// Reading them is reported
+	read := r.Data(nil)
+	read.SetId(pk)
+	diags = r.ReadContext(context.Background(), read, p.Meta())
+	assert.False(t, diags.HasError(), diags)
+	assert.Len(t, diags, 1)
+	assert.Equal(t, diag.Warning, diags[0].Severity)
+	assert.Equal(t, "Object is owned by another workspace", diags[0].Summary)
+	assert.Equal(t, "{}", read.Get("attributes"))
+	diags = r.UpdateContext(context.Background(), d, p.Meta())
+	assert.True(t, diags.HasError())
+	assert.Equal(t, "Object is owned by another workspace", diags[0].Summary)
+	assert.Contains(t, diags[0].Detail, `"workspace-b"`)
+	diags = r.DeleteContext(context.Background(), d, p.Meta())
+	assert.True(t, diags.HasError())
+	obj, ok := testServer.Get("/core/groups/", pk)
+	assert.True(t, ok)
+	assert.Equal(t, "foreign-group", obj["name"])
+
+	// Objects deleted outside of Terraform are handled by Update and Delete
+	missing := r.Data(nil)
+	missing.SetId("00000000-0000-0000-0000-000000000000")
+	assert.NoError(t, missing.Set("name", "missing-group"))
+	diags = r.UpdateContext(context.Background(), missing, p.Meta())
+	assert.True(t, diags.HasError())
+	assert.Equal(t, "Object was deleted outside of Terraform", diags[0].Summary)
+	diags = r.DeleteContext(context.Background(), missing, p.Meta())
+	assert.False(t, diags.HasError(), diags)
+
+	// Without an ownership key, stamps are ignored
+	p = testProviderWithConfig(t, map[string]interface{}{})
+	r = p.ResourcesMap["authentik_group"]
+	diags = r.UpdateContext(context.Background(), d, p.Meta())
+	assert.False(t, diags.HasError(), diags)
+}
+
+func Test_ownedResources(t *testing.T) {
+	p := Provider("test", false)
+	for name := range ownedResources {
+		r, ok := p.ResourcesMap[name]
+		assert.True(t, ok, name)
+		assert.Contains(t, r.Schema, "attributes", name)
+	}
+}
@@ -9,9 +9,10 @@ import (
 	"net/url"
 	"os"
 	"strings"
+	"time"
 
-	"github.com/getsentry/sentry-go"
 	httptransport "github.com/go-openapi/runtime/client"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	api "goauthentik.io/api/v3"
@@ -38,6 +39,16 @@ func init() {
 
 // Provider -
 func Provider(version string, testing bool) *schema.Provider {
+	var transport http.RoundTripper
+	if testing {
+		transport = NewTestingTransport(http.DefaultTransport)
+	}
+	return providerWithTransport(version, transport)
+}
+
+// providerWithTransport Provider which sends all API requests through transport instead of
+// connecting to authentik, if transport is set
+func providerWithTransport(version string, transport http.RoundTripper) *schema.Provider {
 	return &schema.Provider{
 		Schema: map[string]*schema.Schema{
 			"url": {
@@ -52,104 +63,323 @@ func Provider(version string, testing bool) *schema.Provider {
 				DefaultFunc: schema.EnvDefaultFunc("AUTHENTIK_INSECURE", false),
 				Description: "Whether to skip TLS verification, can optionally be passed as `AUTHENTIK_INSECURE` environmental variable",
 			},
+			"ca_cert_pem": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("AUTHENTIK_CA_CERT_PEM", nil),
+				Description: "PEM-encoded CA certificate bundle used to verify the authentik server, replaces the system trust store. Can optionally be passed as `AUTHENTIK_CA_CERT_PEM` environmental variable",
+			},
+			"ca_cert_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("AUTHENTIK_CA_CERT_FILE", nil),
+				Description: "Path to a PEM-encoded CA certificate bundle used to verify the authentik server, replaces the system trust store. Can optionally be passed as `AUTHENTIK_CA_CERT_FILE` environmental variable",
+			},
+			"client_cert_pem": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("AUTHENTIK_CLIENT_CERT_PEM", nil),
+				Description: "PEM-encoded client certificate for mutual TLS, requires `client_key_pem`. Can optionally be passed as `AUTHENTIK_CLIENT_CERT_PEM` environmental variable",
+			},
+			"client_key_pem": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("AUTHENTIK_CLIENT_KEY_PEM", nil),
+				Sensitive:   true,
+				Description: "PEM-encoded RSA or ECDSA private key of the client certificate. Can optionally be passed as `AUTHENTIK_CLIENT_KEY_PEM` environmental variable",
+			},
+			"tls_server_name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("AUTHENTIK_TLS_SERVER_NAME", nil),
+				Description: "Server name used to verify the certificate of the authentik server, if it differs from the host in `url`. Can optionally be passed as `AUTHENTIK_TLS_SERVER_NAME` environmental variable",
+			},
 			"token": {
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
 				DefaultFunc: schema.EnvDefaultFunc("AUTHENTIK_TOKEN", nil),
 				Sensitive:   true,
-				Description: "The authentik API token, can optionally be passed as `AUTHENTIK_TOKEN` environmental variable",
+				Description: "The authentik API token, can optionally be passed as `AUTHENTIK_TOKEN` environmental variable. Exactly one of `token`, `token_file`, `exec` or `client_credentials` must be configured.",
+			},
+			"token_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("AUTHENTIK_TOKEN_FILE", nil),
+				Description: "Path to a file containing the authentik API token, which is read every time the provider is configured. Can optionally be passed as `AUTHENTIK_TOKEN_FILE` environmental variable",
+			},
+			"exec": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "Run a credential helper to retrieve the API token. The command must print a JSON object like `{\"token\": \"...\", \"expiration\": \"2006-01-02T15:04:05Z\"}` to stdout, `expiration` is optional and causes the command to be run again once the token expires.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"command": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"args": {
+							Type:     schema.TypeList,
+							Optional: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"env": {
+							Type:     schema.TypeMap,
+							Optional: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+					},
+				},
+			},
+			"client_credentials": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "Authenticate as a service account using the OAuth2 client_credentials grant. The app password of the service account is exchanged for a short-lived JWT at `/application/o/token/`, which is refreshed when it expires.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"client_id": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "Client ID of the OAuth2 provider issuing the token",
+						},
+						"username": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "Username of the service account",
+						},
+						"password": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Sensitive:   true,
+							Description: "App password of the service account",
+						},
+						"scope": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Default:     "goauthentik.io/api",
+							Description: "Space-separated scopes to request, must include the authentik API scope",
+						},
+					},
+				},
+			},
+			"telemetry": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "Send traces of provider operations and API requests to Sentry or an OpenTelemetry collector. Nothing is sent unless `enabled` is set.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"enabled": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  false,
+						},
+						"backend": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Default:     telemetryBackendOTLP,
+							Description: "Either `otlp` or `sentry`.",
+						},
+						"sample_rate": {
+							Type:        schema.TypeFloat,
+							Optional:    true,
+							Default:     1.0,
+							Description: "Fraction of traces to sample, between 0 and 1.",
+						},
+						"sentry_dsn": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Sensitive:   true,
+							Description: "Sentry DSN to send traces to. Falls back to the `SENTRY_DSN` environmental variable and the DSN configured in authentik.",
+						},
+						"otlp_endpoint": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "URL of the OTLP/HTTP collector, for example `https://otel-collector:4318`. When unset, the standard `OTEL_EXPORTER_OTLP_*` environmental variables are used.",
+						},
+						"otlp_headers": {
+							Type:        schema.TypeMap,
+							Optional:    true,
+							Sensitive:   true,
+							Description: "Additional headers sent to the OTLP collector, for example for authentication.",
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+					},
+				},
+			},
+			"max_retries": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("AUTHENTIK_MAX_RETRIES", 4),
+				Description: "Maximum number of times a rate-limited, failed or interrupted request is retried (defaults to 4), can optionally be passed as `AUTHENTIK_MAX_RETRIES` environmental variable",
+			},
+			"retry_wait_min": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("AUTHENTIK_RETRY_WAIT_MIN", 1),
+				Description: "Minimum time in seconds to wait before retrying a request (defaults to 1), with 0 the wait starts at 100 milliseconds. Can optionally be passed as `AUTHENTIK_RETRY_WAIT_MIN` environmental variable",
+			},
+			"retry_wait_max": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("AUTHENTIK_RETRY_WAIT_MAX", 30),
+				Description: "Maximum time in seconds to wait before retrying a request, also caps `Retry-After` headers sent by the server (defaults to 30), can optionally be passed as `AUTHENTIK_RETRY_WAIT_MAX` environmental variable",
+			},
+			"max_concurrent_requests": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("AUTHENTIK_MAX_CONCURRENT_REQUESTS", 0),
+				Description: "Maximum number of API requests sent at the same time, further requests are queued in order. Unlimited when set to 0 (the default), can optionally be passed as `AUTHENTIK_MAX_CONCURRENT_REQUESTS` environmental variable",
+			},
+			"requests_per_second": {
+				Type:        schema.TypeFloat,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("AUTHENTIK_REQUESTS_PER_SECOND", 0),
+				Description: "Maximum number of API requests sent per second, further requests are queued in order. Unlimited when set to 0 (the default), can optionally be passed as `AUTHENTIK_REQUESTS_PER_SECOND` environmental variable",
+			},
+			"cache_ttl": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("AUTHENTIK_CACHE_TTL", 30),
+				Description: "Time in seconds for which responses of API lookups are cached, so that data sources and resources reading the same objects or lists only send one request. Cached responses are discarded when the provider changes an object of the same API group, but changes made outside of Terraform within this time aren't seen by the same run. Defaults to 30 seconds, set to 0 to disable the cache. Can optionally be passed as `AUTHENTIK_CACHE_TTL` environmental variable",
+			},
+			"default_attributes": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Attributes which are merged into the `attributes` of all users, groups and tenants. Attributes set on a resource take precedence. Default attributes are not shown as changes unless they're modified outside of Terraform.",
+			},
+			"adopt_existing": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("AUTHENTIK_ADOPT_EXISTING", false),
+				Description: "When an object can't be created because an object with the same natural key (like the slug of a flow or the name of a group) already exists, update the existing object and take it into the state instead of failing. Can be overridden per resource, and can optionally be passed as `AUTHENTIK_ADOPT_EXISTING` environmental variable",
+			},
+			"ownership_key": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("AUTHENTIK_OWNERSHIP_KEY", ""),
+				Description: "Identifies this workspace when several workspaces or administrators manage the same authentik instance. Users, groups and tenants are stamped with the `terraform/workspace` attribute set to this key when they're created or updated, and objects stamped with a different key are never updated or deleted, a warning is shown when they are read. Other objects don't have attributes in authentik, so they aren't stamped and aren't protected by this key. Can optionally be passed as `AUTHENTIK_OWNERSHIP_KEY` environmental variable",
+			},
+			"log_bodies": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("AUTHENTIK_LOG_BODIES", false),
+				Description: "Include request and response bodies in the debug logs of API requests, shown with `TF_LOG_PROVIDER=DEBUG`. Sensitive fields are redacted. Can optionally be passed as `AUTHENTIK_LOG_BODIES` environmental variable",
 			},
 		},
-		ResourcesMap: map[string]*schema.Resource{
-			"authentik_application":                   tr(resourceApplication),
-			"authentik_blueprint":                     tr(resourceBlueprintInstance),
-			"authentik_certificate_key_pair":          tr(resourceCertificateKeyPair),
-			"authentik_enterprise_license":            tr(resourceEnterpriseLicense),
-			"authentik_event_rule":                    tr(resourceEventRule),
-			"authentik_event_transport":               tr(resourceEventTransport),
-			"authentik_flow_stage_binding":            tr(resourceFlowStageBinding),
-			"authentik_flow":                          tr(resourceFlow),
-			"authentik_group":                         tr(resourceGroup),
-			"authentik_outpost":                       tr(resourceOutpost),
-			"authentik_policy_binding":                tr(resourcePolicyBinding),
-			"authentik_policy_dummy":                  tr(resourcePolicyDummy),
-			"authentik_policy_event_matcher":          tr(resourcePolicyEventMatcher),
-			"authentik_policy_expiry":                 tr(resourcePolicyExpiry),
-			"authentik_policy_expression":             tr(resourcePolicyExpression),
-			"authentik_policy_password":               tr(resourcePolicyPassword),
-			"authentik_policy_reputation":             tr(resourcePolicyReputation),
-			"authentik_property_mapping_ldap":         tr(resourceLDAPPropertyMapping),
-			"authentik_property_mapping_notification": tr(resourceNotificationPropertyMapping),
-			"authentik_property_mapping_saml":         tr(resourceSAMLPropertyMapping),
-			"authentik_property_mapping_scim":         tr(resourceSCIMPropertyMapping),
-			"authentik_provider_ldap":                 tr(resourceProviderLDAP),
-			"authentik_provider_oauth2":               tr(resourceProviderOAuth2),
-			"authentik_provider_proxy":                tr(resourceProviderProxy),
-			"authentik_provider_radius":               tr(resourceProviderRadius),
-			"authentik_provider_saml":                 tr(resourceProviderSAML),
-			"authentik_provider_scim":                 tr(resourceProviderSCIM),
-			"authentik_scope_mapping":                 tr(resourceScopeMapping),
-			"authentik_service_connection_docker":     tr(resourceServiceConnectionDocker),
-			"authentik_service_connection_kubernetes": tr(resourceServiceConnectionKubernetes),
-			"authentik_source_ldap":                   tr(resourceSourceLDAP),
-			"authentik_source_oauth":                  tr(resourceSourceOAuth),
-			"authentik_source_plex":                   tr(resourceSourcePlex),
-			"authentik_source_saml":                   tr(resourceSourceSAML),
-			"authentik_stage_authenticator_duo":       tr(resourceStageAuthenticatorDuo),
-			"authentik_stage_authenticator_sms":       tr(resourceStageAuthenticatorSms),
-			"authentik_stage_authenticator_static":    tr(resourceStageAuthenticatorStatic),
-			"authentik_stage_authenticator_totp":      tr(resourceStageAuthenticatorTOTP),
-			"authentik_stage_authenticator_validate":  tr(resourceStageAuthenticatorValidate),
-			"authentik_stage_authenticator_webauthn":  tr(resourceStageAuthenticatorWebAuthn),
-			"authentik_stage_captcha":                 tr(resourceStageCaptcha),
-			"authentik_stage_consent":                 tr(resourceStageConsent),
-			"authentik_stage_deny":                    tr(resourceStageDeny),
-			"authentik_stage_dummy":                   tr(resourceStageDummy),
-			"authentik_stage_email":                   tr(resourceStageEmail),
-			"authentik_stage_identification":          tr(resourceStageIdentification),
-			"authentik_stage_invitation":              tr(resourceStageInvitation),
-			"authentik_stage_password":                tr(resourceStagePassword),
-			"authentik_stage_prompt_field":            tr(resourceStagePromptField),
-			"authentik_stage_prompt":                  tr(resourceStagePrompt),
-			"authentik_stage_user_delete":             tr(resourceStageUserDelete),
-			"authentik_stage_user_login":              tr(resourceStageUserLogin),
-			"authentik_stage_user_logout":             tr(resourceStageUserLogout),
-			"authentik_stage_user_write":              tr(resourceStageUserWrite),
-			"authentik_tenant":                        tr(resourceTenant),
-			"authentik_token":                         tr(resourceToken),
-			"authentik_user":                          tr(resourceUser),
-		},
-		DataSourcesMap: map[string]*schema.Resource{
-			"authentik_certificate_key_pair":   td(dataSourceCertificateKeyPair),
-			"authentik_flow":                   td(dataSourceFlow),
-			"authentik_group":                  td(dataSourceGroup),
-			"authentik_groups":                 td(dataSourceGroups),
-			"authentik_property_mapping_ldap":  td(dataSourceLDAPPropertyMapping),
-			"authentik_property_mapping_saml":  td(dataSourceSAMLPropertyMapping),
-			"authentik_property_mapping_scim":  td(dataSourceSCIMropertyMapping),
-			"authentik_provider_oauth2_config": td(dataSourceProviderOAuth2Config),
-			"authentik_provider_saml_metadata": td(dataSourceProviderSAMLMetadata),
-			"authentik_scope_mapping":          td(dataSourceScopeMapping),
-			"authentik_source":                 td(dataSourceSource),
-			"authentik_stage":                  td(dataSourceStage),
-			"authentik_tenant":                 td(dataSourceTenant),
-			"authentik_user":                   td(dataSourceUser),
-			"authentik_users":                  td(dataSourceUsers),
-		},
-		ConfigureContextFunc: providerConfigure(version, testing),
+		ResourcesMap: withOwnership(withManagedProtection(withAdoption(tracedResources(map[string]func() *schema.Resource{
+			"authentik_application":                   resourceApplication,
+			"authentik_blueprint":                     resourceBlueprintInstance,
+			"authentik_certificate_key_pair":          resourceCertificateKeyPair,
+			"authentik_enterprise_license":            resourceEnterpriseLicense,
+			"authentik_event_rule":                    resourceEventRule,
+			"authentik_event_transport":               resourceEventTransport,
+			"authentik_flow_stage_binding":            resourceFlowStageBinding,
+			"authentik_flow":                          resourceFlow,
+			"authentik_group":                         resourceGroup,
+			"authentik_object":                        resourceObject,
+			"authentik_outpost":                       resourceOutpost,
+			"authentik_policy_binding":                resourcePolicyBinding,
+			"authentik_policy_dummy":                  resourcePolicyDummy,
+			"authentik_policy_event_matcher":          resourcePolicyEventMatcher,
+			"authentik_policy_expiry":                 resourcePolicyExpiry,
+			"authentik_policy_expression":             resourcePolicyExpression,
+			"authentik_policy_password":               resourcePolicyPassword,
+			"authentik_policy_reputation":             resourcePolicyReputation,
+			"authentik_property_mapping_ldap":         resourceLDAPPropertyMapping,
+			"authentik_property_mapping_notification": resourceNotificationPropertyMapping,
+			"authentik_property_mapping_saml":         resourceSAMLPropertyMapping,
+			"authentik_property_mapping_scim":         resourceSCIMPropertyMapping,
+			"authentik_provider_ldap":                 resourceProviderLDAP,
+			"authentik_provider_oauth2":               resourceProviderOAuth2,
+			"authentik_provider_proxy":                resourceProviderProxy,
+			"authentik_provider_radius":               resourceProviderRadius,
+			"authentik_provider_saml":                 resourceProviderSAML,
+			"authentik_provider_scim":                 resourceProviderSCIM,
+			"authentik_scope_mapping":                 resourceScopeMapping,
+			"authentik_service_connection_docker":     resourceServiceConnectionDocker,
+			"authentik_service_connection_kubernetes": resourceServiceConnectionKubernetes,
+			"authentik_source_ldap":                   resourceSourceLDAP,
+			"authentik_source_oauth":                  resourceSourceOAuth,
+			"authentik_source_plex":                   resourceSourcePlex,
+			"authentik_source_saml":                   resourceSourceSAML,
+			"authentik_stage_authenticator_duo":       resourceStageAuthenticatorDuo,
+			"authentik_stage_authenticator_sms":       resourceStageAuthenticatorSms,
+			"authentik_stage_authenticator_static":    resourceStageAuthenticatorStatic,
+			"authentik_stage_authenticator_totp":      resourceStageAuthenticatorTOTP,
+			"authentik_stage_authenticator_validate":  resourceStageAuthenticatorValidate,
+			"authentik_stage_authenticator_webauthn":  resourceStageAuthenticatorWebAuthn,
+			"authentik_stage_captcha":                 resourceStageCaptcha,
+			"authentik_stage_consent":                 resourceStageConsent,
+			"authentik_stage_deny":                    resourceStageDeny,
+			"authentik_stage_dummy":                   resourceStageDummy,
+			"authentik_stage_email":                   resourceStageEmail,
+			"authentik_stage_identification":          resourceStageIdentification,
+			"authentik_stage_invitation":              resourceStageInvitation,
+			"authentik_stage_password":                resourceStagePassword,
+			"authentik_stage_prompt_field":            resourceStagePromptField,
+			"authentik_stage_prompt":                  resourceStagePrompt,
+			"authentik_stage_user_delete":             resourceStageUserDelete,
+			"authentik_stage_user_login":              resourceStageUserLogin,
+			"authentik_stage_user_logout":             resourceStageUserLogout,
+			"authentik_stage_user_write":              resourceStageUserWrite,
+			"authentik_tenant":                        resourceTenant,
+			"authentik_token":                         resourceToken,
+			"authentik_user":                          resourceUser,
+		})))),
+		DataSourcesMap: tracedDataSources(map[string]func() *schema.Resource{
+			"authentik_api_request":            dataSourceAPIRequest,
+			"authentik_certificate_key_pair":   dataSourceCertificateKeyPair,
+			"authentik_flow":                   dataSourceFlow,
+			"authentik_group":                  dataSourceGroup,
+			"authentik_groups":                 dataSourceGroups,
+			"authentik_property_mapping_ldap":  dataSourceLDAPPropertyMapping,
+			"authentik_property_mapping_saml":  dataSourceSAMLPropertyMapping,
+			"authentik_property_mapping_scim":  dataSourceSCIMropertyMapping,
+			"authentik_provider_oauth2_config": dataSourceProviderOAuth2Config,
+			"authentik_provider_saml_metadata": dataSourceProviderSAMLMetadata,
+			"authentik_scope_mapping":          dataSourceScopeMapping,
+			"authentik_source":                 dataSourceSource,
+			"authentik_stage":                  dataSourceStage,
+			"authentik_tenant":                 dataSourceTenant,
+			"authentik_user":                   dataSourceUser,
+			"authentik_users":                  dataSourceUsers,
+		}),
+		ConfigureContextFunc: providerConfigure(version, transport),
 	}
 }
 
 // APIClient Hold the API Client and any relevant configuration
 type APIClient struct {
 	client *api.APIClient
+	// version of the authentik server, empty if it couldn't be retrieved
+	version string
+	// defaultAttributes are merged into the attributes of users, groups and tenants
+	defaultAttributes map[string]interface{}
+	// adoptExisting Adopt existing objects instead of failing to create them, see withAdoption
+	adoptExisting bool
+	// ownershipKey Objects are stamped with this key, and objects stamped with another key aren't changed, see withOwnership
+	ownershipKey string
 }
 
-func providerConfigure(version string, testing bool) schema.ConfigureContextFunc {
+func providerConfigure(version string, transport http.RoundTripper) schema.ConfigureContextFunc {
 	return func(c context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
 		apiURL := d.Get("url").(string)
-		token := d.Get("token").(string)
-		insecure := d.Get("insecure").(bool)
+		maxRetries := d.Get("max_retries").(int)
+		retryWaitMin := time.Duration(d.Get("retry_wait_min").(int)) * time.Second
+		retryWaitMax := time.Duration(d.Get("retry_wait_max").(int)) * time.Second
+		logBodies := d.Get("log_bodies").(bool)
+		maxConcurrent := d.Get("max_concurrent_requests").(int)
+		requestsPerSecond := d.Get("requests_per_second").(float64)
+		cacheTTL := time.Duration(d.Get("cache_ttl").(int)) * time.Second
 
 		// Warning or errors can be collected in a slice type
 		var diags diag.Diagnostics
@@ -159,50 +389,75 @@ func providerConfigure(version string, testing bool) schema.ConfigureContextFunc
 			return nil, diag.FromErr(err)
 		}
 
+		tlsOptions, err := tlsClientOptions(d)
+		if err != nil {
+			return nil, diag.FromErr(err)
+		}
+		tlsTransport, err := GetTLSTransport(tlsOptions)
+		if err != nil {
+			return nil, diag.FromErr(err)
+		}
+
 		config := api.NewConfiguration()
-		config.Debug = true
 		config.UserAgent = fmt.Sprintf("authentik-terraform@%s", version)
 		config.Host = akURL.Host
 		config.Scheme = akURL.Scheme
-		if testing {
+		if transport != nil {
 			config.HTTPClient = &http.Client{
-				Transport: NewTestingTransport(GetTLSTransport(insecure)),
+				Transport: NewLimitTransport(NewLoggingTransport(transport, logBodies), maxConcurrent, requestsPerSecond),
 			}
 		} else {
 			config.HTTPClient = &http.Client{
-				Transport: GetTLSTransport(insecure),
+				Transport: NewRetryTransport(
+					NewLimitTransport(NewLoggingTransport(tlsTransport, logBodies), maxConcurrent, requestsPerSecond),
+					maxRetries, retryWaitMin, retryWaitMax,
+				),
 			}
 		}
 
-		config.AddDefaultHeader("Authorization", fmt.Sprintf("Bearer %s", token))
-		apiClient := api.NewAPIClient(config)
-
-		rootConfig, _, err := apiClient.RootApi.RootConfigRetrieve(context.Background()).Execute()
-		if err == nil && rootConfig.ErrorReporting.Enabled {
-			dsn := "https://7b485fd979bf48c1acbe38ffe382a541@sentry.beryju.org/14"
-			// Customisable Sentry DSN was added in 2022.11, so only use that DSN when its set
-			if rootConfig.ErrorReporting.SentryDsn != "" {
-				dsn = rootConfig.ErrorReporting.SentryDsn
-			}
-			if envDsn, found := os.LookupEnv("SENTRY_DSN"); found {
-				dsn = envDsn
-			}
-			err := sentry.Init(sentry.ClientOptions{
-				Dsn:              dsn,
-				EnableTracing:    true,
-				Environment:      rootConfig.ErrorReporting.Environment,
-				TracesSampleRate: float64(rootConfig.ErrorReporting.TracesSampleRate),
-				Release:          fmt.Sprintf("terraform-provider-authentik@%s", version),
-			})
+		if mode := os.Getenv(recordEnv); mode != "" && transport == nil {
+			recorder, err := NewRecordingTransport(config.HTTPClient.Transport, mode, os.Getenv(cassetteEnv))
 			if err != nil {
-				fmt.Printf("Error during sentry init: %v\n", err)
+				return nil, diag.FromErr(err)
 			}
-			config.HTTPClient.Transport = NewTracingTransport(context.Background(), config.HTTPClient.Transport)
+			config.HTTPClient.Transport = recorder
+		}
+
+		tokens, err := providerTokenSource(d, akURL, config.HTTPClient)
+		if err != nil {
+			return nil, diag.FromErr(err)
+		}
+		config.HTTPClient = &http.Client{
+			Transport: NewCacheTransport(NewAuthTransport(config.HTTPClient.Transport, tokens), cacheTTL),
+		}
+		apiClient := api.NewAPIClient(config)
+
+		telemetry, telemetryDiags := configureTelemetry(c, d, version, apiClient)
+		diags = append(diags, telemetryDiags...)
+		if diags.HasError() {
+			return nil, diags
+		}
+		if telemetry {
+			config.HTTPClient.Transport = NewTracingTransport(config.HTTPClient.Transport)
 			apiClient = api.NewAPIClient(config)
 		}
 
+		version := ""
+		serverVersion, _, err := apiClient.AdminApi.AdminVersionRetrieve(c).Execute()
+		if err == nil {
+			version = serverVersion.VersionCurrent
+		} else {
+			tflog.Warn(c, "authentik: failed to retrieve server version, skipping version checks", map[string]interface{}{
+				"error": err.Error(),
+			})
+		}
+
 		return &APIClient{
-			client: apiClient,
+			client:            apiClient,
+			version:           version,
+			defaultAttributes: d.Get("default_attributes").(map[string]interface{}),
+			adoptExisting:     d.Get("adopt_existing").(bool),
+			ownershipKey:      d.Get("ownership_key").(string),
 		}, diags
 	}
 }
@@ -233,33 +488,8 @@ func (tt *TestingTransport) RoundTrip(r *http.Request) (*http.Response, error) {
 	}, nil
 }
 
-// GetTLSTransport Get a TLS transport instance, that skips verification if configured via environment variables.
-func GetTLSTransport(insecure bool) http.RoundTripper {
-	tlsTransport, err := httptransport.TLSTransport(httptransport.TLSClientOptions{
-		InsecureSkipVerify: insecure,
-	})
-	if err != nil {
-		panic(err)
-	}
-	return tlsTransport
-}
-
-type tracingTransport struct {
-	inner http.RoundTripper
-	ctx   context.Context
-}
-
-func NewTracingTransport(ctx context.Context, inner http.RoundTripper) *tracingTransport {
-	return &tracingTransport{inner, ctx}
-}
-
-func (tt *tracingTransport) RoundTrip(r *http.Request) (*http.Response, error) {
-	span := sentry.StartSpan(tt.ctx, "authentik.go.http_request")
-	r.Header.Set("sentry-trace", span.ToSentryTrace())
-	span.Description = fmt.Sprintf("%s %s", r.Method, r.URL.String())
-	span.SetTag("url", r.URL.String())
-	span.SetTag("method", r.Method)
-	defer span.Finish()
-	res, err := tt.inner.RoundTrip(r.WithContext(span.Context()))
-	return res, err
+// GetTLSTransport Get a TLS transport instance, configured with the custom CA, client certificate
+// and verification settings from the provider configuration.
+func GetTLSTransport(opts httptransport.TLSClientOptions) (http.RoundTripper, error) {
+	return httptransport.TLSTransport(opts)
 }
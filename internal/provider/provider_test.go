@@ -1,10 +1,20 @@
 package provider
 
 import (
+	"context"
+	"hash/fnv"
+	"math/rand"
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"goauthentik.io/terraform-provider-authentik/internal/fakeauthentik"
 )
 
 // providerFactories are used to instantiate a provider during acceptance testing.
@@ -16,20 +26,120 @@ var providerFactories = map[string]func() (*schema.Provider, error){
 	},
 }
 
+// testServer In-memory authentik API used by providerTestFactories. It is shared by the providers
+// created for every Terraform CLI command, so that objects persist between test steps.
+var testServer = fakeauthentik.New()
+
+// providerTestFactories are used to run acceptance scenarios against testServer with
+// resource.UnitTest, without a live authentik instance or network access.
 var providerTestFactories = map[string]func() (*schema.Provider, error){
 	"authentik": func() (*schema.Provider, error) {
-		return Provider("test", true), nil
+		p := providerWithTransport("test", testServer.Transport())
+		p.Schema["url"].DefaultFunc = schema.EnvDefaultFunc("AUTHENTIK_URL", "http://authentik.invalid")
+		p.Schema["token"].DefaultFunc = schema.EnvDefaultFunc("AUTHENTIK_TOKEN", "test")
+		// Tests change objects on testServer directly, which the cache wouldn't see
+		p.Schema["cache_ttl"].DefaultFunc = schema.EnvDefaultFunc("AUTHENTIK_CACHE_TTL", 0)
+		return p, nil
+	},
+}
+
+// providerFailingFactories are used to check how failed requests are handled, every request fails
+// with a 400 response.
+var providerFailingFactories = map[string]func() (*schema.Provider, error){
+	"authentik": func() (*schema.Provider, error) {
+		p := Provider("test", true)
+		p.Schema["url"].DefaultFunc = schema.EnvDefaultFunc("AUTHENTIK_URL", "http://authentik.invalid")
+		p.Schema["token"].DefaultFunc = schema.EnvDefaultFunc("AUTHENTIK_TOKEN", "test")
+		return p, nil
 	},
 }
 
+// testAccLive Check if acceptance scenarios run against a live authentik instance, which is the case
+// when AUTHENTIK_URL is set or requests are recorded or replayed. Otherwise they run against testServer.
+func testAccLive() bool {
+	return os.Getenv("AUTHENTIK_URL") != "" || os.Getenv(recordEnv) != ""
+}
+
+// testAccProviderFactories Providers for acceptance scenarios, see testAccLive
+func testAccProviderFactories() map[string]func() (*schema.Provider, error) {
+	if testAccLive() {
+		return providerFactories
+	}
+	return providerTestFactories
+}
+
 func TestProvider(t *testing.T) {
 	p := Provider("testing", false)
 	if err := p.InternalValidate(); err != nil {
 		t.Fatalf("err: %[1]s", err)
 	}
+
+	// API lookups are cached unless it's disabled with 0
+	t.Setenv("AUTHENTIK_CACHE_TTL", "")
+	ttl, err := p.Schema["cache_ttl"].DefaultValue()
+	assert.NoError(t, err)
+	assert.Equal(t, 30, ttl)
+}
+
+func TestProviderTestFactories(t *testing.T) {
+	p, err := providerTestFactories["authentik"]()
+	assert.NoError(t, err)
+	diags := p.Configure(context.Background(), terraform.NewResourceConfigRaw(map[string]interface{}{}))
+	assert.False(t, diags.HasError())
+	assert.Equal(t, testServer.Version, p.Meta().(*APIClient).version)
+
+	r := p.ResourcesMap["authentik_group"]
+	d := r.TestResourceData()
+	assert.NoError(t, d.Set("name", "test-factories"))
+	diags = r.CreateContext(context.Background(), d, p.Meta())
+	assert.False(t, diags.HasError())
+	_, ok := testServer.Get("/core/groups/", d.Id())
+	assert.True(t, ok)
+
+	diags = r.DeleteContext(context.Background(), d, p.Meta())
+	assert.False(t, diags.HasError())
+	_, ok = testServer.Get("/core/groups/", d.Id())
+	assert.False(t, ok)
+}
+
+// testAccCassette Record the API requests of the current test to a cassette in testdata/cassettes, or
+// replay them without an authentik instance, depending on AUTHENTIK_RECORD. The returned random source
+// is seeded from the test name, so that random names are the same when recording and replaying.
+func testAccCassette(t *testing.T) *rand.Rand {
+	mode := os.Getenv(recordEnv)
+	if mode == "" {
+		return rand.New(rand.NewSource(time.Now().UnixNano()))
+	}
+	t.Setenv(cassetteEnv, filepath.Join("testdata", "cassettes", strings.ReplaceAll(t.Name(), "/", "_")+".json"))
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(t.Name()))
+	if mode == recordModeReplay {
+		// Requests are never sent when replaying, the URL and token only need to be set
+		for k, v := range map[string]string{
+			"AUTHENTIK_URL":   "http://localhost:9000",
+			"AUTHENTIK_TOKEN": redacted,
+		} {
+			if os.Getenv(k) == "" {
+				t.Setenv(k, v)
+			}
+		}
+	}
+	return rand.New(rand.NewSource(int64(h.Sum64())))
+}
+
+// testAccRandName Random name for the objects created by an acceptance test
+func testAccRandName(rnd *rand.Rand) string {
+	b := make([]byte, 10)
+	for i := range b {
+		b[i] = acctest.CharSetAlphaNum[rnd.Intn(len(acctest.CharSetAlphaNum))]
+	}
+	return string(b)
 }
 
 func testAccPreCheck(t *testing.T) {
+	if !testAccLive() {
+		return
+	}
 	testEnvIsSet("AUTHENTIK_URL", t)
 	testEnvIsSet("AUTHENTIK_TOKEN", t)
 }
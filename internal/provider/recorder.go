@@ -0,0 +1,237 @@
+package provider
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+const (
+	// recordEnv Environment variable to record or replay API requests, either `record` or `replay`
+	recordEnv = "AUTHENTIK_RECORD"
+	// cassetteEnv Environment variable with the path of the file requests are recorded to or replayed from
+	cassetteEnv = "AUTHENTIK_CASSETTE"
+
+	recordModeRecord = "record"
+	recordModeReplay = "replay"
+)
+
+type cassetteRequest struct {
+	Method  string      `json:"method"`
+	URL     string      `json:"url"`
+	Headers http.Header `json:"headers,omitempty"`
+	Body    string      `json:"body,omitempty"`
+}
+
+type cassetteResponse struct {
+	StatusCode int         `json:"status_code"`
+	Headers    http.Header `json:"headers,omitempty"`
+	Body       string      `json:"body,omitempty"`
+}
+
+type cassetteInteraction struct {
+	Request  cassetteRequest  `json:"request"`
+	Response cassetteResponse `json:"response"`
+}
+
+// cassette Recorded request/response pairs of a single test
+type cassette struct {
+	Interactions []*cassetteInteraction `json:"interactions"`
+
+	path string
+	mu   sync.Mutex
+	used []bool
+	// values Sensitive values sent while replaying, by their placeholder
+	values map[string]string
+}
+
+var (
+	// cassettes Open cassettes by their path. A new provider is configured for every Terraform
+	// command of a test, so cassettes are shared between them.
+	cassettes   = map[string]*cassette{}
+	cassettesMu sync.Mutex
+)
+
+// openCassette Get the cassette at path, which is started from scratch when recording
+func openCassette(mode string, path string) (*cassette, error) {
+	cassettesMu.Lock()
+	defer cassettesMu.Unlock()
+	if c, ok := cassettes[path]; ok {
+		return c, nil
+	}
+	c := &cassette{
+		Interactions: []*cassetteInteraction{},
+		path:         path,
+		values:       map[string]string{},
+	}
+	if mode == recordModeReplay {
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read cassette: %w", err)
+		}
+		err = json.Unmarshal(b, c)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse cassette %s: %w", path, err)
+		}
+		c.used = make([]bool, len(c.Interactions))
+	}
+	cassettes[path] = c
+	return c, nil
+}
+
+// add Append an interaction and write the cassette, as there is no point at which
+// the provider knows that a test is finished
+func (c *cassette) add(i *cassetteInteraction) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.Interactions = append(c.Interactions, i)
+	b, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+	err = os.MkdirAll(filepath.Dir(c.path), 0o755)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(c.path, b, 0o644)
+}
+
+// redact Redact a body for the cassette, the values of placeholderFields are replaced by their
+// placeholder, which is remembered to restore them in replayed responses
+func (c *cassette) redact(contentType string, body []byte) string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return redactBodyWith(contentType, body, func(field string, value string) string {
+		if !placeholderFields[field] {
+			return redacted
+		}
+		p := placeholder(value)
+		if p != value {
+			c.values[p] = value
+		}
+		return p
+	})
+}
+
+// restore Replace the placeholders of values which were sent while replaying with the values, so that
+// replayed responses contain the same values as the configuration
+func (c *cassette) restore(contentType string, body string) string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return redactBodyWith(contentType, []byte(body), func(field string, value string) string {
+		if v, ok := c.values[value]; ok && placeholderFields[field] {
+			return v
+		}
+		return value
+	})
+}
+
+// next Find the first interaction with the same method, URL and redacted body which hasn't been
+// replayed yet
+func (c *cassette) next(method string, url string, body string) *cassetteInteraction {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for idx, i := range c.Interactions {
+		if c.used[idx] || i.Request.Method != method || i.Request.URL != url || i.Request.Body != body {
+			continue
+		}
+		c.used[idx] = true
+		return i
+	}
+	return nil
+}
+
+// recordingTransport Transport which records all requests and responses to a cassette, or
+// replays responses from a cassette without sending any requests
+type recordingTransport struct {
+	inner    http.RoundTripper
+	mode     string
+	cassette *cassette
+}
+
+// NewRecordingTransport Get a HTTP transport that records to or replays from the cassette at path,
+// depending on mode
+func NewRecordingTransport(inner http.RoundTripper, mode string, path string) (*recordingTransport, error) {
+	if mode != recordModeRecord && mode != recordModeReplay {
+		return nil, fmt.Errorf("invalid %s mode %s, expected one of `%s` or `%s`", recordEnv, mode, recordModeRecord, recordModeReplay)
+	}
+	if path == "" {
+		return nil, fmt.Errorf("%s is set, but no cassette is configured in %s", recordEnv, cassetteEnv)
+	}
+	c, err := openCassette(mode, path)
+	if err != nil {
+		return nil, err
+	}
+	return &recordingTransport{inner, mode, c}, nil
+}
+
+// RoundTrip HTTP Transport
+func (rt *recordingTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	url := r.URL.RequestURI()
+	var reqBody []byte
+	if r.Body != nil {
+		b, err := io.ReadAll(r.Body)
+		if err != nil {
+			return nil, err
+		}
+		_ = r.Body.Close()
+		reqBody = b
+		r = r.Clone(r.Context())
+		r.Body = io.NopCloser(bytes.NewReader(b))
+	}
+	contentType := r.Header.Get("Content-Type")
+
+	if rt.mode == recordModeReplay {
+		i := rt.cassette.next(r.Method, url, rt.cassette.redact(contentType, reqBody))
+		if i == nil {
+			return nil, fmt.Errorf("no recorded response for %s %s in cassette %s", r.Method, url, rt.cassette.path)
+		}
+		body := rt.cassette.restore(i.Response.Headers.Get("Content-Type"), i.Response.Body)
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", i.Response.StatusCode, http.StatusText(i.Response.StatusCode)),
+			StatusCode:    i.Response.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        i.Response.Headers.Clone(),
+			Body:          io.NopCloser(strings.NewReader(body)),
+			ContentLength: int64(len(body)),
+			Request:       r,
+		}, nil
+	}
+
+	res, err := rt.inner.RoundTrip(r)
+	if err != nil {
+		return res, err
+	}
+	resBody, err := io.ReadAll(res.Body)
+	_ = res.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	res.Body = io.NopCloser(bytes.NewReader(resBody))
+
+	err = rt.cassette.add(&cassetteInteraction{
+		Request: cassetteRequest{
+			Method:  r.Method,
+			URL:     url,
+			Headers: redactHeaders(r.Header),
+			Body:    rt.cassette.redact(contentType, reqBody),
+		},
+		Response: cassetteResponse{
+			StatusCode: res.StatusCode,
+			Headers:    redactHeaders(res.Header),
+			Body:       rt.cassette.redact(res.Header.Get("Content-Type"), resBody),
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to write cassette: %w", err)
+	}
+	return res, nil
+}
@@ -0,0 +1,135 @@
+package provider
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_recordingTransport(t *testing.T) {
+	calls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls += 1
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/api/v3/core/tokens/foo/view_key/" {
+			_, _ = w.Write([]byte(`{"key": "very-secret"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"name": "` + r.URL.Query().Get("name") + `"}`))
+	}))
+	defer srv.Close()
+	path := filepath.Join(t.TempDir(), "cassette.json")
+
+	record, err := NewRecordingTransport(http.DefaultTransport, recordModeRecord, path)
+	assert.NoError(t, err)
+	client := &http.Client{Transport: NewAuthTransport(record, staticTokenSource("secret-token"))}
+	for _, u := range []string{"/api/v3/core/groups/?name=a", "/api/v3/core/groups/?name=b", "/api/v3/core/tokens/foo/view_key/"} {
+		res, err := client.Get(srv.URL + u)
+		assert.NoError(t, err)
+		_ = res.Body.Close()
+	}
+	assert.Equal(t, 3, calls)
+
+	b, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.NotContains(t, string(b), "secret-token")
+	assert.NotContains(t, string(b), "very-secret")
+
+	// Replaying doesn't send any requests, and matches requests by method, URL and body
+	delete(cassettes, path)
+	replay, err := NewRecordingTransport(nil, recordModeReplay, path)
+	assert.NoError(t, err)
+	client = &http.Client{Transport: replay}
+	for _, name := range []string{"b", "a"} {
+		res, err := client.Get(srv.URL + "/api/v3/core/groups/?name=" + name)
+		assert.NoError(t, err)
+		body, err := io.ReadAll(res.Body)
+		assert.NoError(t, err)
+		assert.Equal(t, `{"name":"`+name+`"}`, strings.TrimSpace(string(body)))
+	}
+	assert.Equal(t, 3, calls)
+
+	// Every interaction is only replayed once
+	_, err = client.Get(srv.URL + "/api/v3/core/groups/?name=a")
+	assert.ErrorContains(t, err, "no recorded response for GET /api/v3/core/groups/?name=a")
+}
+
+func Test_NewRecordingTransport_Invalid(t *testing.T) {
+	_, err := NewRecordingTransport(nil, "foo", "cassette.json")
+	assert.Error(t, err)
+	_, err = NewRecordingTransport(nil, recordModeReplay, "")
+	assert.Error(t, err)
+	_, err = NewRecordingTransport(nil, recordModeReplay, filepath.Join(t.TempDir(), "missing.json"))
+	assert.Error(t, err)
+}
+
+func Test_recordingTransport_Placeholders(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/api/v3/core/tokens/foo/view_key/" {
+			_, _ = w.Write([]byte(`{"key": "generated-key"}`))
+			return
+		}
+		// Echo the request, like the response of a create or update
+		body, _ := io.ReadAll(r.Body)
+		_, _ = w.Write(body)
+	}))
+	defer srv.Close()
+	path := filepath.Join(t.TempDir(), "cassette.json")
+
+	send := func(client *http.Client, method string, url string, body string) (string, error) {
+		req, err := http.NewRequest(method, srv.URL+url, strings.NewReader(body))
+		assert.NoError(t, err)
+		req.Header.Set("Content-Type", "application/json")
+		res, err := client.Do(req)
+		if err != nil {
+			return "", err
+		}
+		b, err := io.ReadAll(res.Body)
+		assert.NoError(t, err)
+		return strings.TrimSpace(string(b)), nil
+	}
+
+	record, err := NewRecordingTransport(http.DefaultTransport, recordModeRecord, path)
+	assert.NoError(t, err)
+	client := &http.Client{Transport: record}
+	_, err = send(client, http.MethodPost, "/api/v3/core/tokens/", `{"identifier": "foo", "key": "configured-key"}`)
+	assert.NoError(t, err)
+	_, err = send(client, http.MethodGet, "/api/v3/core/tokens/foo/view_key/", "")
+	assert.NoError(t, err)
+	_, err = send(client, http.MethodPut, "/api/v3/core/tokens/foo/", `{"identifier": "foo", "key": "generated-key"}`)
+	assert.NoError(t, err)
+
+	b, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.NotContains(t, string(b), "configured-key")
+	assert.NotContains(t, string(b), "generated-key")
+	assert.Contains(t, string(b), placeholder("configured-key"))
+
+	delete(cassettes, path)
+	replay, err := NewRecordingTransport(nil, recordModeReplay, path)
+	assert.NoError(t, err)
+	client = &http.Client{Transport: replay}
+
+	// Requests are matched by their body as well
+	_, err = send(client, http.MethodPost, "/api/v3/core/tokens/", `{"identifier": "bar", "key": "configured-key"}`)
+	assert.ErrorContains(t, err, "no recorded response for POST /api/v3/core/tokens/")
+
+	// Values sent by the provider are restored in responses
+	body, err := send(client, http.MethodPost, "/api/v3/core/tokens/", `{"key": "configured-key", "identifier": "foo"}`)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"identifier": "foo", "key": "configured-key"}`, body)
+
+	// Values only returned by authentik are replayed as their placeholder, which matches when it's sent again
+	body, err = send(client, http.MethodGet, "/api/v3/core/tokens/foo/view_key/", "")
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"key": "`+placeholder("generated-key")+`"}`, body)
+	_, err = send(client, http.MethodPut, "/api/v3/core/tokens/foo/", `{"identifier": "foo", "key": "`+placeholder("generated-key")+`"}`)
+	assert.NoError(t, err)
+}
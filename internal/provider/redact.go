@@ -0,0 +1,151 @@
+package provider
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"mime"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// redacted Placeholder for sensitive values in logs and recorded requests
+const redacted = "REDACTED"
+
+// redactedHeaders Headers which are never logged or written to a cassette
+var redactedHeaders = []string{"Authorization", "Cookie", "Set-Cookie"}
+
+// redactedFields JSON fields whose values are never logged or written to a cassette
+var redactedFields = map[string]bool{
+	"access_token":    true,
+	"bind_password":   true,
+	"client_secret":   true,
+	"consumer_secret": true,
+	"key":             true,
+	"key_data":        true,
+	"password":        true,
+	"private_key":     true,
+	"refresh_token":   true,
+	"secret":          true,
+	"secret_key":      true,
+	"token":           true,
+}
+
+// placeholderFields Sensitive JSON fields whose values are also written to the state, like the key of a
+// token. In cassettes, their values are replaced by a placeholder derived from the value instead, so that
+// the same value always gets the same placeholder and replayed requests and responses still match.
+var placeholderFields = map[string]bool{
+	"client_secret": true,
+	"key":           true,
+	"key_data":      true,
+	"token":         true,
+}
+
+// placeholderPattern Placeholders returned by placeholder
+var placeholderPattern = regexp.MustCompile(`^` + redacted + `-[0-9a-f]{16}$`)
+
+// placeholder Stable placeholder for a sensitive value. Placeholders are kept as they are, as they
+// end up in the state when replaying values that were never sent by the provider.
+func placeholder(value string) string {
+	if placeholderPattern.MatchString(value) {
+		return value
+	}
+	sum := sha256.Sum256([]byte(value))
+	return redacted + "-" + hex.EncodeToString(sum[:8])
+}
+
+// redactField Replace the value of a sensitive field
+type redactField func(field string, value string) string
+
+func redactAll(field string, value string) string {
+	return redacted
+}
+
+// redactHeaders Copy headers with the values of sensitive headers replaced
+func redactHeaders(h http.Header) http.Header {
+	r := h.Clone()
+	for _, name := range redactedHeaders {
+		if r.Get(name) != "" {
+			r.Set(name, redacted)
+		}
+	}
+	return r
+}
+
+// redactBody Replace the values of sensitive fields in a JSON or form-encoded body, depending on its
+// content type. Other bodies are returned as-is.
+func redactBody(contentType string, body []byte) string {
+	return redactBodyWith(contentType, body, redactAll)
+}
+
+// redactBodyWith Like redactBody, but with replace called for the value of every sensitive field
+func redactBodyWith(contentType string, body []byte, replace redactField) string {
+	if len(body) < 1 {
+		return ""
+	}
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	switch {
+	case mediaType == "application/x-www-form-urlencoded":
+		return redactForm(body, replace)
+	case mediaType == "application/json" || strings.HasSuffix(mediaType, "+json"):
+		return redactJSON(body, replace)
+	}
+	return string(body)
+}
+
+func redactJSON(body []byte, replace redactField) string {
+	var v interface{}
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.UseNumber()
+	if err := dec.Decode(&v); err != nil {
+		return string(body)
+	}
+	b, err := json.Marshal(redactValue(v, replace))
+	if err != nil {
+		return string(body)
+	}
+	return string(b)
+}
+
+// redactForm Replace the values of sensitive fields in a form-encoded body, like the token requests of
+// the client_credentials authentication. Bodies which can't be parsed are redacted entirely.
+func redactForm(body []byte, replace redactField) string {
+	values, err := url.ParseQuery(string(body))
+	if err != nil {
+		return redacted
+	}
+	for k, vv := range values {
+		field := strings.ToLower(k)
+		if !redactedFields[field] {
+			continue
+		}
+		for idx, v := range vv {
+			if v != "" {
+				vv[idx] = replace(field, v)
+			}
+		}
+	}
+	return values.Encode()
+}
+
+func redactValue(v interface{}, replace redactField) interface{} {
+	switch vv := v.(type) {
+	case map[string]interface{}:
+		for k, value := range vv {
+			field := strings.ToLower(k)
+			if s, ok := value.(string); ok && s != "" && redactedFields[field] {
+				vv[k] = replace(field, s)
+				continue
+			}
+			vv[k] = redactValue(value, replace)
+		}
+	case []interface{}:
+		for idx, value := range vv {
+			vv[idx] = redactValue(value, replace)
+		}
+	}
+	return v
+}
@@ -0,0 +1,34 @@
+package provider
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_redactBody(t *testing.T) {
+	assert.Equal(t, `{"name":"foo","nested":[{"client_secret":"REDACTED"}],"password":"REDACTED","pk":5}`,
+		redactBody("application/json", []byte(`{"name": "foo", "password": "bar", "pk": 5, "nested": [{"client_secret": "baz"}]}`)))
+	assert.Equal(t, `{"key":""}`, redactBody("application/json; charset=utf-8", []byte(`{"key": ""}`)))
+	assert.Equal(t, "not json", redactBody("application/json", []byte("not json")))
+	assert.Equal(t, `{"password": "bar"}`, redactBody("text/plain", []byte(`{"password": "bar"}`)))
+}
+
+func Test_redactBody_Form(t *testing.T) {
+	assert.Equal(t, "client_id=terraform&grant_type=client_credentials&password=REDACTED&username=svc",
+		redactBody("application/x-www-form-urlencoded", []byte("grant_type=client_credentials&client_id=terraform&username=svc&password=app-password")))
+	assert.Equal(t, "password=", redactBody("application/x-www-form-urlencoded", []byte("password=")))
+	assert.Equal(t, redacted, redactBody("application/x-www-form-urlencoded", []byte("password=%zz")))
+}
+
+func Test_redactHeaders(t *testing.T) {
+	h := http.Header{}
+	h.Set("Authorization", "Bearer foo")
+	h.Set("Content-Type", "application/json")
+	r := redactHeaders(h)
+	assert.Equal(t, redacted, r.Get("Authorization"))
+	assert.Equal(t, "application/json", r.Get("Content-Type"))
+	// The original headers are still sent
+	assert.Equal(t, "Bearer foo", h.Get("Authorization"))
+}
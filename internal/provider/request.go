@@ -0,0 +1,89 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// apiRequest Send a request to a path of the authentik API, like `/core/users/`, with the HTTP
+// client of the generated client, so that requests are authenticated, limited and retried the same
+// way. Error responses are returned like the generated client does, so they can be passed to httpToDiag.
+func (c *APIClient) apiRequest(ctx context.Context, method string, path string, query url.Values, body []byte) ([]byte, *http.Response, error) {
+	config := c.client.GetConfig()
+	base, err := config.ServerURLWithContext(ctx, "")
+	if err != nil {
+		return nil, nil, err
+	}
+	u := url.URL{
+		Scheme:   config.Scheme,
+		Host:     config.Host,
+		Path:     strings.TrimSuffix(base, "/") + apiPath(path),
+		RawQuery: query.Encode(),
+	}
+	var reqBody io.Reader
+	if body != nil {
+		reqBody = bytes.NewReader(body)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), reqBody)
+	if err != nil {
+		return nil, nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.Header.Set("User-Agent", config.UserAgent)
+	for k, v := range config.DefaultHeader {
+		req.Header.Set(k, v)
+	}
+
+	hr, err := config.HTTPClient.Do(req)
+	if err != nil {
+		return nil, hr, err
+	}
+	res, err := io.ReadAll(hr.Body)
+	_ = hr.Body.Close()
+	// The body is kept readable for httpToDiag
+	hr.Body = io.NopCloser(bytes.NewReader(res))
+	if err != nil {
+		return nil, hr, err
+	}
+	if hr.StatusCode >= 300 {
+		return nil, hr, errors.New(hr.Status)
+	}
+	return res, hr, nil
+}
+
+// apiPath Normalize an API path to the form `/core/users/`
+func apiPath(path string) string {
+	return "/" + strings.Trim(path, "/") + "/"
+}
+
+// decodeJSON Decode a JSON value, keeping numbers as json.Number so that IDs aren't converted to floats
+func decodeJSON(raw []byte) (interface{}, error) {
+	var v interface{}
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	err := dec.Decode(&v)
+	return v, err
+}
+
+// decodeJSONObject Decode a JSON object, see decodeJSON
+func decodeJSONObject(raw []byte) (map[string]interface{}, error) {
+	v, err := decodeJSON(raw)
+	if err != nil {
+		return nil, err
+	}
+	obj, ok := v.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("expected a JSON object")
+	}
+	return obj, nil
+}
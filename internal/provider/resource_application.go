@@ -14,9 +14,15 @@ func resourceApplication() *schema.Resource {
 		ReadContext:   resourceApplicationRead,
 		UpdateContext: resourceApplicationUpdate,
 		DeleteContext: resourceApplicationDelete,
-		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
-		},
+		Timeouts:      defaultTimeouts(),
+		CustomizeDiff: customizeDiffVersion("", map[string]string{
+			"backchannel_providers": "2023.5",
+		}),
+		Importer: importStateByKey("slug", map[string]importListFunc{
+			"slug": func(ctx context.Context, c *APIClient, slug string, page int32) importPage {
+				return importList(c.client.CoreApi.CoreApplicationsList(ctx).Slug(slug).Page(page).Execute())
+			},
+		}),
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:     schema.TypeString,
@@ -63,9 +69,10 @@ func resourceApplication() *schema.Resource {
 				Optional: true,
 			},
 			"policy_engine_mode": {
-				Type:     schema.TypeString,
-				Optional: true,
-				Default:  api.POLICYENGINEMODE_ANY,
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          api.POLICYENGINEMODE_ANY,
+				ValidateDiagFunc: validateEnum(api.AllowedPolicyEngineModeEnumValues),
 			},
 			"open_in_new_tab": {
 				Type:     schema.TypeBool,
@@ -139,7 +146,7 @@ func resourceApplicationRead(ctx context.Context, d *schema.ResourceData, m inte
 
 	res, hr, err := c.client.CoreApi.CoreApplicationsRetrieve(ctx, d.Id()).Execute()
 	if err != nil {
-		return httpToDiag(d, hr, err)
+		return httpOperationToDiag(d, operationRead, hr, err)
 	}
 
 	d.SetId(res.Slug)
@@ -170,7 +177,7 @@ func resourceApplicationUpdate(ctx context.Context, d *schema.ResourceData, m in
 
 	res, hr, err := c.client.CoreApi.CoreApplicationsUpdate(ctx, d.Id()).ApplicationRequest(*app).Execute()
 	if err != nil {
-		return httpToDiag(d, hr, err)
+		return httpOperationToDiag(d, operationUpdate, hr, err)
 	}
 
 	if i, iok := d.GetOk("meta_icon"); iok {
@@ -190,7 +197,7 @@ func resourceApplicationDelete(ctx context.Context, d *schema.ResourceData, m in
 	c := m.(*APIClient)
 	hr, err := c.client.CoreApi.CoreApplicationsDestroy(ctx, d.Id()).Execute()
 	if err != nil {
-		return httpToDiag(d, hr, err)
+		return httpOperationToDiag(d, operationDelete, hr, err)
 	}
 	return diag.Diagnostics{}
 }
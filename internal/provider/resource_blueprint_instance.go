@@ -16,9 +16,12 @@ func resourceBlueprintInstance() *schema.Resource {
 		ReadContext:   resourceBlueprintInstanceRead,
 		UpdateContext: resourceBlueprintInstanceUpdate,
 		DeleteContext: resourceBlueprintInstanceDelete,
-		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
-		},
+		Timeouts:      defaultTimeouts(),
+		Importer: importStateByKey("pk", map[string]importListFunc{
+			"name": func(ctx context.Context, c *APIClient, name string, page int32) importPage {
+				return importList(c.client.ManagedApi.ManagedBlueprintsList(ctx).Name(name).Page(page).Execute())
+			},
+		}),
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:     schema.TypeString,
@@ -96,7 +99,7 @@ func resourceBlueprintInstanceRead(ctx context.Context, d *schema.ResourceData,
 
 	res, hr, err := c.client.ManagedApi.ManagedBlueprintsRetrieve(ctx, d.Id()).Execute()
 	if err != nil {
-		return httpToDiag(d, hr, err)
+		return httpOperationToDiag(d, operationRead, hr, err)
 	}
 
 	setWrapper(d, "name", res.Name)
@@ -121,7 +124,7 @@ func resourceBlueprintInstanceUpdate(ctx context.Context, d *schema.ResourceData
 
 	res, hr, err := c.client.ManagedApi.ManagedBlueprintsUpdate(ctx, d.Id()).BlueprintInstanceRequest(*app).Execute()
 	if err != nil {
-		return httpToDiag(d, hr, err)
+		return httpOperationToDiag(d, operationUpdate, hr, err)
 	}
 
 	d.SetId(res.Pk)
@@ -132,7 +135,7 @@ func resourceBlueprintInstanceDelete(ctx context.Context, d *schema.ResourceData
 	c := m.(*APIClient)
 	hr, err := c.client.ManagedApi.ManagedBlueprintsDestroy(ctx, d.Id()).Execute()
 	if err != nil {
-		return httpToDiag(d, hr, err)
+		return httpOperationToDiag(d, operationDelete, hr, err)
 	}
 	return diag.Diagnostics{}
 }
@@ -14,9 +14,12 @@ func resourceCertificateKeyPair() *schema.Resource {
 		ReadContext:   resourceCertificateKeyPairRead,
 		UpdateContext: resourceCertificateKeyPairUpdate,
 		DeleteContext: resourceCertificateKeyPairDelete,
-		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
-		},
+		Timeouts:      defaultTimeouts(),
+		Importer: importStateByKey("pk", map[string]importListFunc{
+			"name": func(ctx context.Context, c *APIClient, name string, page int32) importPage {
+				return importList(c.client.CryptoApi.CryptoCertificatekeypairsList(ctx).Name(name).Page(page).Execute())
+			},
+		}),
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:     schema.TypeString,
@@ -67,14 +70,15 @@ func resourceCertificateKeyPairRead(ctx context.Context, d *schema.ResourceData,
 
 	res, hr, err := c.client.CryptoApi.CryptoCertificatekeypairsRetrieve(ctx, d.Id()).Execute()
 	if err != nil {
-		return httpToDiag(d, hr, err)
+		return httpOperationToDiag(d, operationRead, hr, err)
 	}
 
 	setWrapper(d, "name", res.Name)
+	setWrapper(d, "managed", res.Managed.Get())
 
 	rc, hr, err := c.client.CryptoApi.CryptoCertificatekeypairsViewCertificateRetrieve(ctx, d.Id()).Execute()
 	if err != nil {
-		return httpToDiag(d, hr, err)
+		return httpOperationToDiag(d, operationRead, hr, err)
 	}
 	setWrapper(d, "certificate_data", rc.Data+"\n")
 
@@ -93,7 +97,7 @@ func resourceCertificateKeyPairUpdate(ctx context.Context, d *schema.ResourceDat
 
 	res, hr, err := c.client.CryptoApi.CryptoCertificatekeypairsUpdate(ctx, d.Id()).CertificateKeyPairRequest(*app).Execute()
 	if err != nil {
-		return httpToDiag(d, hr, err)
+		return httpOperationToDiag(d, operationUpdate, hr, err)
 	}
 
 	d.SetId(res.Pk)
@@ -104,7 +108,7 @@ func resourceCertificateKeyPairDelete(ctx context.Context, d *schema.ResourceDat
 	c := m.(*APIClient)
 	hr, err := c.client.CryptoApi.CryptoCertificatekeypairsDestroy(ctx, d.Id()).Execute()
 	if err != nil {
-		return httpToDiag(d, hr, err)
+		return httpOperationToDiag(d, operationDelete, hr, err)
 	}
 	return diag.Diagnostics{}
 }
@@ -9,11 +9,12 @@ import (
 	"fmt"
 	"log"
 	"math/big"
+	"os"
+	"path/filepath"
 	"regexp"
 	"testing"
 	"time"
 
-	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 )
 
@@ -64,15 +65,33 @@ func GenerateSelfSignedCert() (string, string, error) {
 	return string(pemBytes), string(privPemByes), nil
 }
 
+// testAccCertificate Certificate and key for acceptance tests. A fixed pair from testdata is used when
+// requests are recorded or replayed, so that request bodies match the cassette.
+func testAccCertificate() (string, string, error) {
+	if os.Getenv(recordEnv) == "" {
+		return GenerateSelfSignedCert()
+	}
+	cert, err := os.ReadFile(filepath.Join("testdata", "certificate.pem"))
+	if err != nil {
+		return "", "", err
+	}
+	key, err := os.ReadFile(filepath.Join("testdata", "certificate_key.pem"))
+	if err != nil {
+		return "", "", err
+	}
+	return string(cert), string(key), nil
+}
+
 func TestAccResourceCertificateKeyPair(t *testing.T) {
-	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
-	cert, key, err := GenerateSelfSignedCert()
+	rnd := testAccCassette(t)
+	rName := testAccRandName(rnd)
+	cert, key, err := testAccCertificate()
 	if err != nil {
 		t.Fatal(err)
 	}
 	resource.UnitTest(t, resource.TestCase{
 		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: providerFactories,
+		ProviderFactories: testAccProviderFactories(),
 		Steps: []resource.TestStep{
 			{
 				Config: testAccResourceCertificateKeyPairSimple(rName, cert, key),
@@ -92,7 +111,7 @@ func TestAccResourceCertificateKeyPair(t *testing.T) {
 	})
 	resource.UnitTest(t, resource.TestCase{
 		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: providerTestFactories,
+		ProviderFactories: providerFailingFactories,
 		Steps: []resource.TestStep{
 			{
 				Config:      testAccResourceCertificateKeyPairSimple(rName, cert, key),
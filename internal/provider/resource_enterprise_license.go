@@ -14,6 +14,8 @@ func resourceEnterpriseLicense() *schema.Resource {
 		ReadContext:   resourceEnterpriseLicenseRead,
 		UpdateContext: resourceEnterpriseLicenseUpdate,
 		DeleteContext: resourceEnterpriseLicenseDelete,
+		Timeouts:      defaultTimeouts(),
+		CustomizeDiff: customizeDiffVersion("2023.8", nil),
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -70,7 +72,7 @@ func resourceEnterpriseLicenseRead(ctx context.Context, d *schema.ResourceData,
 
 	res, hr, err := c.client.EnterpriseApi.EnterpriseLicenseRetrieve(ctx, d.Id()).Execute()
 	if err != nil {
-		return httpToDiag(d, hr, err)
+		return httpOperationToDiag(d, operationRead, hr, err)
 	}
 
 	setWrapper(d, "name", res.Name)
@@ -88,7 +90,7 @@ func resourceEnterpriseLicenseUpdate(ctx context.Context, d *schema.ResourceData
 
 	res, hr, err := c.client.EnterpriseApi.EnterpriseLicenseUpdate(ctx, d.Id()).LicenseRequest(*app).Execute()
 	if err != nil {
-		return httpToDiag(d, hr, err)
+		return httpOperationToDiag(d, operationUpdate, hr, err)
 	}
 
 	d.SetId(res.LicenseUuid)
@@ -99,7 +101,7 @@ func resourceEnterpriseLicenseDelete(ctx context.Context, d *schema.ResourceData
 	c := m.(*APIClient)
 	hr, err := c.client.EnterpriseApi.EnterpriseLicenseDestroy(ctx, d.Id()).Execute()
 	if err != nil {
-		return httpToDiag(d, hr, err)
+		return httpOperationToDiag(d, operationDelete, hr, err)
 	}
 	return diag.Diagnostics{}
 }
@@ -14,9 +14,12 @@ func resourceEventRule() *schema.Resource {
 		ReadContext:   resourceEventRuleRead,
 		UpdateContext: resourceEventRuleUpdate,
 		DeleteContext: resourceEventRuleDelete,
-		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
-		},
+		Timeouts:      defaultTimeouts(),
+		Importer: importStateByKey("pk", map[string]importListFunc{
+			"name": func(ctx context.Context, c *APIClient, name string, page int32) importPage {
+				return importList(c.client.EventsApi.EventsRulesList(ctx).Name(name).Page(page).Execute())
+			},
+		}),
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:     schema.TypeString,
@@ -30,9 +33,10 @@ func resourceEventRule() *schema.Resource {
 				},
 			},
 			"severity": {
-				Type:     schema.TypeString,
-				Optional: true,
-				Default:  api.SEVERITYENUM_WARNING,
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          api.SEVERITYENUM_WARNING,
+				ValidateDiagFunc: validateEnum(api.AllowedSeverityEnumEnumValues),
 			},
 			"webhook_mapping": {
 				Type:     schema.TypeString,
@@ -84,7 +88,7 @@ func resourceEventRuleRead(ctx context.Context, d *schema.ResourceData, m interf
 
 	res, hr, err := c.client.EventsApi.EventsRulesRetrieve(ctx, d.Id()).Execute()
 	if err != nil {
-		return httpToDiag(d, hr, err)
+		return httpOperationToDiag(d, operationRead, hr, err)
 	}
 
 	setWrapper(d, "name", res.Name)
@@ -103,7 +107,7 @@ func resourceEventRuleUpdate(ctx context.Context, d *schema.ResourceData, m inte
 	}
 	res, hr, err := c.client.EventsApi.EventsRulesUpdate(ctx, d.Id()).NotificationRuleRequest(*app).Execute()
 	if err != nil {
-		return httpToDiag(d, hr, err)
+		return httpOperationToDiag(d, operationUpdate, hr, err)
 	}
 
 	d.SetId(res.Pk)
@@ -114,7 +118,7 @@ func resourceEventRuleDelete(ctx context.Context, d *schema.ResourceData, m inte
 	c := m.(*APIClient)
 	hr, err := c.client.EventsApi.EventsRulesDestroy(ctx, d.Id()).Execute()
 	if err != nil {
-		return httpToDiag(d, hr, err)
+		return httpOperationToDiag(d, operationDelete, hr, err)
 	}
 	return diag.Diagnostics{}
 }
@@ -14,17 +14,21 @@ func resourceEventTransport() *schema.Resource {
 		ReadContext:   resourceEventTransportRead,
 		UpdateContext: resourceEventTransportUpdate,
 		DeleteContext: resourceEventTransportDelete,
-		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
-		},
+		Timeouts:      defaultTimeouts(),
+		Importer: importStateByKey("pk", map[string]importListFunc{
+			"name": func(ctx context.Context, c *APIClient, name string, page int32) importPage {
+				return importList(c.client.EventsApi.EventsTransportsList(ctx).Name(name).Page(page).Execute())
+			},
+		}),
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:     schema.TypeString,
 				Required: true,
 			},
 			"mode": {
-				Type:     schema.TypeString,
-				Required: true,
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validateEnum(api.AllowedNotificationTransportModeEnumEnumValues),
 			},
 			"webhook_url": {
 				Type:     schema.TypeString,
@@ -84,7 +88,7 @@ func resourceEventTransportRead(ctx context.Context, d *schema.ResourceData, m i
 
 	res, hr, err := c.client.EventsApi.EventsTransportsRetrieve(ctx, d.Id()).Execute()
 	if err != nil {
-		return httpToDiag(d, hr, err)
+		return httpOperationToDiag(d, operationRead, hr, err)
 	}
 
 	setWrapper(d, "name", res.Name)
@@ -104,7 +108,7 @@ func resourceEventTransportUpdate(ctx context.Context, d *schema.ResourceData, m
 	}
 	res, hr, err := c.client.EventsApi.EventsTransportsUpdate(ctx, d.Id()).NotificationTransportRequest(*app).Execute()
 	if err != nil {
-		return httpToDiag(d, hr, err)
+		return httpOperationToDiag(d, operationUpdate, hr, err)
 	}
 
 	d.SetId(res.Pk)
@@ -115,7 +119,7 @@ func resourceEventTransportDelete(ctx context.Context, d *schema.ResourceData, m
 	c := m.(*APIClient)
 	hr, err := c.client.EventsApi.EventsTransportsDestroy(ctx, d.Id()).Execute()
 	if err != nil {
-		return httpToDiag(d, hr, err)
+		return httpOperationToDiag(d, operationDelete, hr, err)
 	}
 	return diag.Diagnostics{}
 }
@@ -14,9 +14,12 @@ func resourceFlow() *schema.Resource {
 		ReadContext:   resourceFlowRead,
 		UpdateContext: resourceFlowUpdate,
 		DeleteContext: resourceFlowDelete,
-		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
-		},
+		Timeouts:      defaultTimeouts(),
+		Importer: importStateByKey("slug", map[string]importListFunc{
+			"slug": func(ctx context.Context, c *APIClient, slug string, page int32) importPage {
+				return importList(c.client.FlowsApi.FlowsInstancesList(ctx).Slug(slug).Page(page).Execute())
+			},
+		}),
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:     schema.TypeString,
@@ -35,18 +38,21 @@ func resourceFlow() *schema.Resource {
 				Required: true,
 			},
 			"designation": {
-				Type:     schema.TypeString,
-				Required: true,
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validateEnum(api.AllowedFlowDesignationEnumEnumValues),
 			},
 			"authentication": {
-				Type:     schema.TypeString,
-				Optional: true,
-				Default:  api.AUTHENTICATIONENUM_NONE,
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          api.AUTHENTICATIONENUM_NONE,
+				ValidateDiagFunc: validateEnum(api.AllowedAuthenticationEnumEnumValues),
 			},
 			"layout": {
-				Type:     schema.TypeString,
-				Optional: true,
-				Default:  "stacked",
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          "stacked",
+				ValidateDiagFunc: validateEnum(api.AllowedLayoutEnumEnumValues),
 			},
 			"background": {
 				Type:        schema.TypeString,
@@ -54,14 +60,16 @@ func resourceFlow() *schema.Resource {
 				Description: "Optional URL to an image which will be used as the background during the flow.",
 			},
 			"policy_engine_mode": {
-				Type:     schema.TypeString,
-				Optional: true,
-				Default:  api.POLICYENGINEMODE_ANY,
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          api.POLICYENGINEMODE_ANY,
+				ValidateDiagFunc: validateEnum(api.AllowedPolicyEngineModeEnumValues),
 			},
 			"denied_action": {
-				Type:     schema.TypeString,
-				Optional: true,
-				Default:  api.DENIEDACTIONENUM_MESSAGE_CONTINUE,
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          api.DENIEDACTIONENUM_MESSAGE_CONTINUE,
+				ValidateDiagFunc: validateEnum(api.AllowedDeniedActionEnumEnumValues),
 			},
 			"compatibility_mode": {
 				Type:     schema.TypeBool,
@@ -116,7 +124,7 @@ func resourceFlowRead(ctx context.Context, d *schema.ResourceData, m interface{}
 
 	res, hr, err := c.client.FlowsApi.FlowsInstancesRetrieve(ctx, d.Id()).Execute()
 	if err != nil {
-		return httpToDiag(d, hr, err)
+		return httpOperationToDiag(d, operationRead, hr, err)
 	}
 
 	setWrapper(d, "uuid", res.Pk)
@@ -142,7 +150,7 @@ func resourceFlowUpdate(ctx context.Context, d *schema.ResourceData, m interface
 
 	res, hr, err := c.client.FlowsApi.FlowsInstancesUpdate(ctx, d.Id()).FlowRequest(*app).Execute()
 	if err != nil {
-		return httpToDiag(d, hr, err)
+		return httpOperationToDiag(d, operationUpdate, hr, err)
 	}
 
 	d.SetId(res.Slug)
@@ -153,7 +161,7 @@ func resourceFlowDelete(ctx context.Context, d *schema.ResourceData, m interface
 	c := m.(*APIClient)
 	hr, err := c.client.FlowsApi.FlowsInstancesDestroy(ctx, d.Id()).Execute()
 	if err != nil {
-		return httpToDiag(d, hr, err)
+		return httpOperationToDiag(d, operationDelete, hr, err)
 	}
 	return diag.Diagnostics{}
 }
@@ -14,9 +14,16 @@ func resourceFlowStageBinding() *schema.Resource {
 		ReadContext:   resourceFlowStageBindingRead,
 		UpdateContext: resourceFlowStageBindingUpdate,
 		DeleteContext: resourceFlowStageBindingDelete,
-		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
-		},
+		Timeouts:      defaultTimeouts(),
+		Importer: importStateByKey("pk", map[string]importListFunc{
+			"target": func(ctx context.Context, c *APIClient, value string, page int32) importPage {
+				target, order, err := parseBindingImportKey(value)
+				if err != nil {
+					return importPage{err: err}
+				}
+				return importList(c.client.FlowsApi.FlowsBindingsList(ctx).Target(target).Order(order).Page(page).Execute())
+			},
+		}),
 		Schema: map[string]*schema.Schema{
 			"target": {
 				Type:     schema.TypeString,
@@ -41,14 +48,16 @@ func resourceFlowStageBinding() *schema.Resource {
 				Required: true,
 			},
 			"policy_engine_mode": {
-				Type:     schema.TypeString,
-				Optional: true,
-				Default:  api.POLICYENGINEMODE_ANY,
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          api.POLICYENGINEMODE_ANY,
+				ValidateDiagFunc: validateEnum(api.AllowedPolicyEngineModeEnumValues),
 			},
 			"invalid_response_action": {
-				Type:     schema.TypeString,
-				Optional: true,
-				Default:  api.INVALIDRESPONSEACTIONENUM_RETRY,
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          api.INVALIDRESPONSEACTIONENUM_RETRY,
+				ValidateDiagFunc: validateEnum(api.AllowedInvalidResponseActionEnumEnumValues),
 			},
 		},
 	}
@@ -87,7 +96,7 @@ func resourceFlowStageBindingRead(ctx context.Context, d *schema.ResourceData, m
 
 	res, hr, err := c.client.FlowsApi.FlowsBindingsRetrieve(ctx, d.Id()).Execute()
 	if err != nil {
-		return httpToDiag(d, hr, err)
+		return httpOperationToDiag(d, operationRead, hr, err)
 	}
 
 	setWrapper(d, "target", res.Target)
@@ -107,7 +116,7 @@ func resourceFlowStageBindingUpdate(ctx context.Context, d *schema.ResourceData,
 
 	res, hr, err := c.client.FlowsApi.FlowsBindingsUpdate(ctx, d.Id()).FlowStageBindingRequest(*app).Execute()
 	if err != nil {
-		return httpToDiag(d, hr, err)
+		return httpOperationToDiag(d, operationUpdate, hr, err)
 	}
 
 	d.SetId(res.Pk)
@@ -118,7 +127,7 @@ func resourceFlowStageBindingDelete(ctx context.Context, d *schema.ResourceData,
 	c := m.(*APIClient)
 	hr, err := c.client.FlowsApi.FlowsBindingsDestroy(ctx, d.Id()).Execute()
 	if err != nil {
-		return httpToDiag(d, hr, err)
+		return httpOperationToDiag(d, operationDelete, hr, err)
 	}
 	return diag.Diagnostics{}
 }
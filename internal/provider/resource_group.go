@@ -2,11 +2,10 @@ package provider
 
 import (
 	"context"
-	"encoding/json"
-	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	api "goauthentik.io/api/v3"
 )
 
@@ -16,9 +15,12 @@ func resourceGroup() *schema.Resource {
 		ReadContext:   resourceGroupRead,
 		UpdateContext: resourceGroupUpdate,
 		DeleteContext: resourceGroupDelete,
-		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
-		},
+		Timeouts:      defaultTimeouts(),
+		Importer: importStateByKey("pk", map[string]importListFunc{
+			"name": func(ctx context.Context, c *APIClient, name string, page int32) importPage {
+				return importList(c.client.CoreApi.CoreGroupsList(ctx).Name(name).Page(page).Execute())
+			},
+		}),
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:     schema.TypeString,
@@ -48,6 +50,19 @@ func resourceGroup() *schema.Resource {
 				Description:      "JSON format expected. Use jsonencode() to pass objects.",
 				DiffSuppressFunc: diffSuppressJSON,
 			},
+			"attributes_mode": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          attributesModeAuthoritative,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{attributesModeAuthoritative, attributesModeMerge}, false)),
+				Description:      "With `authoritative`, all attributes of the group are replaced with `attributes`. With `merge`, only the keys set in `attributes` and `managed_attribute_keys` are read and written, and keys set by authentik, flows or administrators are preserved.",
+			},
+			"managed_attribute_keys": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Keys which are managed by this resource when `attributes_mode` is `merge`, in addition to the keys set in `attributes`. Managed keys which aren't set in `attributes` are removed from the group.",
+			},
 		},
 	}
 }
@@ -68,12 +83,9 @@ func resourceGroupSchemaToModel(d *schema.ResourceData, c *APIClient) (*api.Grou
 		m.Users[i] = int32(prov.(int))
 	}
 
-	attr := make(map[string]interface{})
-	if l, ok := d.Get("attributes").(string); ok && l != "" {
-		err := json.NewDecoder(strings.NewReader(l)).Decode(&attr)
-		if err != nil {
-			return nil, diag.FromErr(err)
-		}
+	attr, diags := attributesSchemaToModel(d, c)
+	if diags != nil {
+		return nil, diags
 	}
 	m.Attributes = attr
 	return &m, nil
@@ -102,16 +114,21 @@ func resourceGroupRead(ctx context.Context, d *schema.ResourceData, m interface{
 
 	res, hr, err := c.client.CoreApi.CoreGroupsRetrieve(ctx, d.Id()).Execute()
 	if err != nil {
-		return httpToDiag(d, hr, err)
+		return httpOperationToDiag(d, operationRead, hr, err)
 	}
 
 	setWrapper(d, "name", res.Name)
 	setWrapper(d, "is_superuser", res.IsSuperuser)
-	b, err := json.Marshal(res.Attributes)
-	if err != nil {
-		return diag.FromErr(err)
+	attr, diags := attributesModelToSchema(d, c, res.Attributes)
+	if diags != nil {
+		return diags
 	}
-	setWrapper(d, "attributes", string(b))
+	setWrapper(d, "attributes", attr)
+	// State written before `attributes_mode` existed doesn't have it, which would show up as a diff
+	if d.Get("attributes_mode").(string) == "" {
+		setWrapper(d, "attributes_mode", attributesModeAuthoritative)
+	}
+	diags = append(diags, ownershipWarning(d, c, res.Attributes)...)
 	localUsers := castSlice[int](d.Get("users").([]interface{}))
 	setWrapper(d, "users", listConsistentMerge(localUsers, slice32ToInt(res.Users)))
 	return diags
@@ -124,9 +141,16 @@ func resourceGroupUpdate(ctx context.Context, d *schema.ResourceData, m interfac
 	if di != nil {
 		return di
 	}
+	if d.Get("attributes_mode").(string) == attributesModeMerge {
+		cur, hr, err := c.client.CoreApi.CoreGroupsRetrieve(ctx, d.Id()).Execute()
+		if err != nil {
+			return httpOperationToDiag(d, operationUpdate, hr, err)
+		}
+		app.Attributes = attributesMerge(d, c, app.Attributes, cur.Attributes)
+	}
 	res, hr, err := c.client.CoreApi.CoreGroupsUpdate(ctx, d.Id()).GroupRequest(*app).Execute()
 	if err != nil {
-		return httpToDiag(d, hr, err)
+		return httpOperationToDiag(d, operationUpdate, hr, err)
 	}
 
 	d.SetId(res.Pk)
@@ -137,7 +161,7 @@ func resourceGroupDelete(ctx context.Context, d *schema.ResourceData, m interfac
 	c := m.(*APIClient)
 	hr, err := c.client.CoreApi.CoreGroupsDestroy(ctx, d.Id()).Execute()
 	if err != nil {
-		return httpToDiag(d, hr, err)
+		return httpOperationToDiag(d, operationDelete, hr, err)
 	}
 	return diag.Diagnostics{}
 }
@@ -0,0 +1,161 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceObject() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Generic object of an API endpoint that isn't supported by a dedicated resource yet. The object is created with a POST request to `path`, and updated with a PUT request to the object's URL.",
+		CreateContext: resourceObjectCreate,
+		ReadContext:   resourceObjectRead,
+		UpdateContext: resourceObjectUpdate,
+		DeleteContext: resourceObjectDelete,
+		Timeouts:      defaultTimeouts(),
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceObjectImport,
+		},
+		CustomizeDiff: func(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+			if d.HasChange("body") {
+				return d.SetNewComputed("response")
+			}
+			return nil
+		},
+		Schema: map[string]*schema.Schema{
+			"path": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Path of the API collection the object is created in, relative to `/api/v3`, like `/rbac/roles/`.",
+			},
+			"body": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateFunc:     validation.StringIsJSON,
+				DiffSuppressFunc: diffSuppressJSON,
+				Description:      "JSON format expected. Use jsonencode() to pass objects. Only the keys set in `body` are compared with the object in authentik.",
+			},
+			"id_field": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "pk",
+				ForceNew:    true,
+				Description: "Field of the response which contains the ID of the object, which is used in the object's URL.",
+			},
+			"ignore_fields": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Keys of `body` which aren't compared with the object in authentik, like write-only fields or values that authentik changes.",
+			},
+			"response": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "JSON response of authentik, including the fields set by authentik.",
+			},
+		},
+	}
+}
+
+// resourceObjectURL Path of the object in its collection
+func resourceObjectURL(d *schema.ResourceData) string {
+	return apiPath(d.Get("path").(string)) + d.Id() + "/"
+}
+
+func resourceObjectCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*APIClient)
+
+	raw, hr, err := c.apiRequest(ctx, http.MethodPost, apiPath(d.Get("path").(string)), nil, []byte(d.Get("body").(string)))
+	if err != nil {
+		return httpToDiag(d, hr, err)
+	}
+	res, err := decodeJSONObject(raw)
+	if err != nil {
+		return diag.Errorf("failed to decode response: %s", err)
+	}
+	idField := d.Get("id_field").(string)
+	id, ok := res[idField]
+	if !ok || id == nil {
+		return diag.Errorf("the response doesn't contain the ID field %q", idField)
+	}
+
+	d.SetId(fmt.Sprint(id))
+	return resourceObjectRead(ctx, d, m)
+}
+
+func resourceObjectRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	c := m.(*APIClient)
+
+	raw, hr, err := c.apiRequest(ctx, http.MethodGet, resourceObjectURL(d), nil, nil)
+	if err != nil {
+		return httpOperationToDiag(d, operationRead, hr, err)
+	}
+	res, err := decodeJSONObject(raw)
+	if err != nil {
+		return diag.Errorf("failed to decode response: %s", err)
+	}
+	setWrapper(d, "response", string(raw))
+
+	// Only the configured keys are read back, all other keys are managed by authentik
+	body := res
+	if old := d.Get("body").(string); old != "" {
+		body, err = decodeJSONObject([]byte(old))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		ignored := castSlice[string](d.Get("ignore_fields").(*schema.Set).List())
+		for k := range body {
+			if v, ok := res[k]; ok && offsetInSlice(k, ignored) == -1 {
+				body[k] = v
+			}
+		}
+	}
+	b, err := json.Marshal(body)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	setWrapper(d, "body", string(b))
+	return diags
+}
+
+func resourceObjectUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*APIClient)
+
+	_, hr, err := c.apiRequest(ctx, http.MethodPut, resourceObjectURL(d), nil, []byte(d.Get("body").(string)))
+	if err != nil {
+		return httpOperationToDiag(d, operationUpdate, hr, err)
+	}
+	return resourceObjectRead(ctx, d, m)
+}
+
+func resourceObjectDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*APIClient)
+	_, hr, err := c.apiRequest(ctx, http.MethodDelete, resourceObjectURL(d), nil, nil)
+	if err != nil {
+		return httpOperationToDiag(d, operationDelete, hr, err)
+	}
+	return diag.Diagnostics{}
+}
+
+// resourceObjectImport Import an object by its URL, like `/rbac/roles/<id>/`. All fields returned
+// by authentik are imported into `body`.
+func resourceObjectImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	path := strings.Trim(d.Id(), "/")
+	idx := strings.LastIndex(path, "/")
+	if idx < 1 {
+		return nil, fmt.Errorf("invalid import ID, expected `<path>/<id>` like `/rbac/roles/<id>`")
+	}
+	setWrapper(d, "path", apiPath(path[:idx]))
+	setWrapper(d, "id_field", "pk")
+	d.SetId(path[idx+1:])
+	return []*schema.ResourceData{d}, nil
+}
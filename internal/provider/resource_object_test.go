@@ -0,0 +1,135 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccResourceObject(t *testing.T) {
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: providerTestFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceObject(rName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("authentik_object.name", "path", "/stages/dummy/"),
+					resource.TestCheckResourceAttrSet("authentik_object.name", "response"),
+				),
+			},
+			{
+				Config: testAccResourceObject(rName + "test"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("authentik_object.name", "body", fmt.Sprintf(`{"name":"%stest"}`, rName)),
+				),
+			},
+			{
+				ResourceName: "authentik_object.name",
+				ImportState:  true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					return "/stages/dummy/" + s.RootModule().Resources["authentik_object.name"].Primary.ID, nil
+				},
+			},
+		},
+	})
+}
+
+func testAccResourceObject(name string) string {
+	return fmt.Sprintf(`
+resource "authentik_object" "name" {
+  path = "/stages/dummy/"
+  body = jsonencode({
+    name = "%[1]s"
+  })
+}
+`, name)
+}
+
+func Test_resourceObject(t *testing.T) {
+	p := testProviderWithConfig(t, map[string]interface{}{})
+	r := p.ResourcesMap["authentik_object"]
+	ctx := context.Background()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"path":          "core/groups",
+		"body":          `{"name": "object-test", "is_superuser": false, "attributes": {"foo": "bar"}}`,
+		"id_field":      "pk",
+		"ignore_fields": []interface{}{"attributes"},
+	})
+	diags := r.CreateContext(ctx, d, p.Meta())
+	assert.False(t, diags.HasError(), diags)
+	defer testServer.Remove("/core/groups/", d.Id())
+	obj, ok := testServer.Get("/core/groups/", d.Id())
+	assert.True(t, ok)
+	assert.Equal(t, "object-test", obj["name"])
+	assert.Contains(t, d.Get("response").(string), d.Id())
+
+	// Changes of configured keys are detected, other keys and ignored keys aren't
+	obj["is_superuser"] = true
+	obj["attributes"] = map[string]interface{}{"foo": "changed"}
+	obj["num_pk"] = 1234
+	diags = r.ReadContext(ctx, d, p.Meta())
+	assert.False(t, diags.HasError(), diags)
+	assert.JSONEq(t, `{"name": "object-test", "is_superuser": true, "attributes": {"foo": "bar"}}`, d.Get("body").(string))
+	assert.Contains(t, d.Get("response").(string), `"num_pk":1234`)
+
+	assert.NoError(t, d.Set("body", `{"name": "object-test-2", "is_superuser": false}`))
+	diags = r.UpdateContext(ctx, d, p.Meta())
+	assert.False(t, diags.HasError(), diags)
+	obj, _ = testServer.Get("/core/groups/", d.Id())
+	assert.Equal(t, "object-test-2", obj["name"])
+	assert.Equal(t, false, obj["is_superuser"])
+
+	// Imported objects contain all fields
+	imported := r.TestResourceData()
+	imported.SetId("/core/groups/" + d.Id())
+	res, err := r.Importer.StateContext(ctx, imported, p.Meta())
+	assert.NoError(t, err)
+	assert.Equal(t, d.Id(), res[0].Id())
+	assert.Equal(t, "/core/groups/", res[0].Get("path"))
+	diags = r.ReadContext(ctx, res[0], p.Meta())
+	assert.False(t, diags.HasError(), diags)
+	assert.Contains(t, res[0].Get("body"), `"num_pk":1234`)
+
+	diags = r.DeleteContext(ctx, d, p.Meta())
+	assert.False(t, diags.HasError(), diags)
+	_, ok = testServer.Get("/core/groups/", d.Id())
+	assert.False(t, ok)
+
+	// Objects deleted outside of Terraform are removed from the state
+	diags = r.ReadContext(ctx, res[0], p.Meta())
+	assert.False(t, diags.HasError(), diags)
+	assert.Equal(t, "", res[0].Id())
+}
+
+func Test_resourceObject_Errors(t *testing.T) {
+	p := testProviderWithConfig(t, map[string]interface{}{})
+	r := p.ResourcesMap["authentik_object"]
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"path":     "/core/groups/",
+		"body":     `{"name": "object-errors"}`,
+		"id_field": "missing",
+	})
+	diags := r.CreateContext(context.Background(), d, p.Meta())
+	assert.True(t, diags.HasError())
+	assert.Equal(t, `the response doesn't contain the ID field "missing"`, diags[0].Summary)
+
+	// Validation errors of authentik are returned with the request they were caused by
+	d = schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"path":     "/core/groups/",
+		"body":     `{"name": ""}`,
+		"id_field": "pk",
+	})
+	diags = r.CreateContext(context.Background(), d, p.Meta())
+	assert.True(t, diags.HasError())
+	assert.Contains(t, diags[0].Detail, "POST /api/v3/core/groups/")
+
+	_, err := r.Importer.StateContext(context.Background(), r.TestResourceData(), p.Meta())
+	assert.Error(t, err)
+}
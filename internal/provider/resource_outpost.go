@@ -16,18 +16,22 @@ func resourceOutpost() *schema.Resource {
 		ReadContext:   resourceOutpostRead,
 		UpdateContext: resourceOutpostUpdate,
 		DeleteContext: resourceOutpostDelete,
-		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
-		},
+		Timeouts:      defaultTimeouts(),
+		Importer: importStateByKey("pk", map[string]importListFunc{
+			"name": func(ctx context.Context, c *APIClient, name string, page int32) importPage {
+				return importList(c.client.OutpostsApi.OutpostsInstancesList(ctx).NameIexact(name).Page(page).Execute())
+			},
+		}),
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:     schema.TypeString,
 				Required: true,
 			},
 			"type": {
-				Type:     schema.TypeString,
-				Optional: true,
-				Default:  api.OUTPOSTTYPEENUM_PROXY,
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          api.OUTPOSTTYPEENUM_PROXY,
+				ValidateDiagFunc: validateEnum(api.AllowedOutpostTypeEnumEnumValues),
 			},
 			"protocol_providers": {
 				Type:     schema.TypeList,
@@ -51,7 +55,7 @@ func resourceOutpost() *schema.Resource {
 	}
 }
 
-func resourceOutpostSchemaToModel(d *schema.ResourceData, c *APIClient) (*api.OutpostRequest, diag.Diagnostics) {
+func resourceOutpostSchemaToModel(ctx context.Context, d *schema.ResourceData, c *APIClient) (*api.OutpostRequest, diag.Diagnostics) {
 	m := api.OutpostRequest{
 		Name: d.Get("name").(string),
 	}
@@ -68,7 +72,7 @@ func resourceOutpostSchemaToModel(d *schema.ResourceData, c *APIClient) (*api.Ou
 		m.ServiceConnection.Set(nil)
 	}
 
-	defaultConfig, hr, err := c.client.OutpostsApi.OutpostsInstancesDefaultSettingsRetrieve(context.Background()).Execute()
+	defaultConfig, hr, err := c.client.OutpostsApi.OutpostsInstancesDefaultSettingsRetrieve(ctx).Execute()
 	if err != nil {
 		return nil, httpToDiag(d, hr, err)
 	}
@@ -90,7 +94,7 @@ func resourceOutpostSchemaToModel(d *schema.ResourceData, c *APIClient) (*api.Ou
 func resourceOutpostCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(*APIClient)
 
-	app, diags := resourceOutpostSchemaToModel(d, c)
+	app, diags := resourceOutpostSchemaToModel(ctx, d, c)
 	if diags != nil {
 		return diags
 	}
@@ -110,10 +114,11 @@ func resourceOutpostRead(ctx context.Context, d *schema.ResourceData, m interfac
 
 	res, hr, err := c.client.OutpostsApi.OutpostsInstancesRetrieve(ctx, d.Id()).Execute()
 	if err != nil {
-		return httpToDiag(d, hr, err)
+		return httpOperationToDiag(d, operationRead, hr, err)
 	}
 
 	setWrapper(d, "name", res.Name)
+	setWrapper(d, "managed", res.Managed.Get())
 	setWrapper(d, "type", res.Type)
 	localProviders := castSlice[int](d.Get("protocol_providers").([]interface{}))
 	setWrapper(d, "protocol_providers", listConsistentMerge(localProviders, slice32ToInt(res.Providers)))
@@ -131,14 +136,14 @@ func resourceOutpostRead(ctx context.Context, d *schema.ResourceData, m interfac
 func resourceOutpostUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(*APIClient)
 
-	app, di := resourceOutpostSchemaToModel(d, c)
+	app, di := resourceOutpostSchemaToModel(ctx, d, c)
 	if di != nil {
 		return di
 	}
 
 	res, hr, err := c.client.OutpostsApi.OutpostsInstancesUpdate(ctx, d.Id()).OutpostRequest(*app).Execute()
 	if err != nil {
-		return httpToDiag(d, hr, err)
+		return httpOperationToDiag(d, operationUpdate, hr, err)
 	}
 
 	d.SetId(res.Pk)
@@ -149,7 +154,7 @@ func resourceOutpostDelete(ctx context.Context, d *schema.ResourceData, m interf
 	c := m.(*APIClient)
 	hr, err := c.client.OutpostsApi.OutpostsInstancesDestroy(ctx, d.Id()).Execute()
 	if err != nil {
-		return httpToDiag(d, hr, err)
+		return httpOperationToDiag(d, operationDelete, hr, err)
 	}
 	return diag.Diagnostics{}
 }
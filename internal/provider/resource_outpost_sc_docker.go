@@ -14,9 +14,12 @@ func resourceServiceConnectionDocker() *schema.Resource {
 		ReadContext:   resourceServiceConnectionDockerRead,
 		UpdateContext: resourceServiceConnectionDockerUpdate,
 		DeleteContext: resourceServiceConnectionDockerDelete,
-		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
-		},
+		Timeouts:      defaultTimeouts(),
+		Importer: importStateByKey("pk", map[string]importListFunc{
+			"name": func(ctx context.Context, c *APIClient, name string, page int32) importPage {
+				return importList(c.client.OutpostsApi.OutpostsServiceConnectionsDockerList(ctx).Name(name).Page(page).Execute())
+			},
+		}),
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:     schema.TypeString,
@@ -87,7 +90,7 @@ func resourceServiceConnectionDockerRead(ctx context.Context, d *schema.Resource
 
 	res, hr, err := c.client.OutpostsApi.OutpostsServiceConnectionsDockerRetrieve(ctx, d.Id()).Execute()
 	if err != nil {
-		return httpToDiag(d, hr, err)
+		return httpOperationToDiag(d, operationRead, hr, err)
 	}
 
 	setWrapper(d, "name", res.Name)
@@ -109,7 +112,7 @@ func resourceServiceConnectionDockerUpdate(ctx context.Context, d *schema.Resour
 
 	res, hr, err := c.client.OutpostsApi.OutpostsServiceConnectionsDockerUpdate(ctx, d.Id()).DockerServiceConnectionRequest(*app).Execute()
 	if err != nil {
-		return httpToDiag(d, hr, err)
+		return httpOperationToDiag(d, operationUpdate, hr, err)
 	}
 
 	d.SetId(res.Pk)
@@ -120,7 +123,7 @@ func resourceServiceConnectionDockerDelete(ctx context.Context, d *schema.Resour
 	c := m.(*APIClient)
 	hr, err := c.client.OutpostsApi.OutpostsServiceConnectionsDockerDestroy(ctx, d.Id()).Execute()
 	if err != nil {
-		return httpToDiag(d, hr, err)
+		return httpOperationToDiag(d, operationDelete, hr, err)
 	}
 	return diag.Diagnostics{}
 }
@@ -14,9 +14,19 @@ func resourcePolicyBinding() *schema.Resource {
 		ReadContext:   resourcePolicyBindingRead,
 		UpdateContext: resourcePolicyBindingUpdate,
 		DeleteContext: resourcePolicyBindingDelete,
-		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
-		},
+		Timeouts:      defaultTimeouts(),
+		CustomizeDiff: customizeDiffVersion("", map[string]string{
+			"failure_result": "2023.8",
+		}),
+		Importer: importStateByKey("pk", map[string]importListFunc{
+			"target": func(ctx context.Context, c *APIClient, value string, page int32) importPage {
+				target, order, err := parseBindingImportKey(value)
+				if err != nil {
+					return importPage{err: err}
+				}
+				return importList(c.client.PoliciesApi.PoliciesBindingsList(ctx).Target(target).Order(order).Page(page).Execute())
+			},
+		}),
 		Schema: map[string]*schema.Schema{
 			"target": {
 				Type:        schema.TypeString,
@@ -119,7 +129,7 @@ func resourcePolicyBindingRead(ctx context.Context, d *schema.ResourceData, m in
 
 	res, hr, err := c.client.PoliciesApi.PoliciesBindingsRetrieve(ctx, d.Id()).Execute()
 	if err != nil {
-		return httpToDiag(d, hr, err)
+		return httpOperationToDiag(d, operationRead, hr, err)
 	}
 
 	setWrapper(d, "target", res.Target)
@@ -147,7 +157,7 @@ func resourcePolicyBindingUpdate(ctx context.Context, d *schema.ResourceData, m
 
 	res, hr, err := c.client.PoliciesApi.PoliciesBindingsUpdate(ctx, d.Id()).PolicyBindingRequest(*app).Execute()
 	if err != nil {
-		return httpToDiag(d, hr, err)
+		return httpOperationToDiag(d, operationUpdate, hr, err)
 	}
 
 	d.SetId(res.Pk)
@@ -158,7 +168,7 @@ func resourcePolicyBindingDelete(ctx context.Context, d *schema.ResourceData, m
 	c := m.(*APIClient)
 	hr, err := c.client.PoliciesApi.PoliciesBindingsDestroy(ctx, d.Id()).Execute()
 	if err != nil {
-		return httpToDiag(d, hr, err)
+		return httpOperationToDiag(d, operationDelete, hr, err)
 	}
 	return diag.Diagnostics{}
 }
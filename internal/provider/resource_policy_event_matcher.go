@@ -14,9 +14,15 @@ func resourcePolicyEventMatcher() *schema.Resource {
 		ReadContext:   resourcePolicyEventMatcherRead,
 		UpdateContext: resourcePolicyEventMatcherUpdate,
 		DeleteContext: resourcePolicyEventMatcherDelete,
-		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
-		},
+		Timeouts:      defaultTimeouts(),
+		CustomizeDiff: customizeDiffVersion("", map[string]string{
+			"model": "2023.6",
+		}),
+		Importer: importStateByKey("pk", map[string]importListFunc{
+			"name": func(ctx context.Context, c *APIClient, name string, page int32) importPage {
+				return importList(c.client.PoliciesApi.PoliciesEventMatcherList(ctx).Name(name).Page(page).Execute())
+			},
+		}),
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:     schema.TypeString,
@@ -36,12 +42,14 @@ func resourcePolicyEventMatcher() *schema.Resource {
 				Optional: true,
 			},
 			"app": {
-				Type:     schema.TypeString,
-				Optional: true,
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: validateEnum(api.AllowedAppEnumEnumValues),
 			},
 			"model": {
-				Type:     schema.TypeString,
-				Optional: true,
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: validateEnum(api.AllowedModelEnumEnumValues),
 			},
 		},
 	}
@@ -88,7 +96,7 @@ func resourcePolicyEventMatcherRead(ctx context.Context, d *schema.ResourceData,
 
 	res, hr, err := c.client.PoliciesApi.PoliciesEventMatcherRetrieve(ctx, d.Id()).Execute()
 	if err != nil {
-		return httpToDiag(d, hr, err)
+		return httpOperationToDiag(d, operationRead, hr, err)
 	}
 
 	setWrapper(d, "name", res.Name)
@@ -115,7 +123,7 @@ func resourcePolicyEventMatcherUpdate(ctx context.Context, d *schema.ResourceDat
 
 	res, hr, err := c.client.PoliciesApi.PoliciesEventMatcherUpdate(ctx, d.Id()).EventMatcherPolicyRequest(*app).Execute()
 	if err != nil {
-		return httpToDiag(d, hr, err)
+		return httpOperationToDiag(d, operationUpdate, hr, err)
 	}
 
 	d.SetId(res.Pk)
@@ -126,7 +134,7 @@ func resourcePolicyEventMatcherDelete(ctx context.Context, d *schema.ResourceDat
 	c := m.(*APIClient)
 	hr, err := c.client.PoliciesApi.PoliciesEventMatcherDestroy(ctx, d.Id()).Execute()
 	if err != nil {
-		return httpToDiag(d, hr, err)
+		return httpOperationToDiag(d, operationDelete, hr, err)
 	}
 	return diag.Diagnostics{}
 }
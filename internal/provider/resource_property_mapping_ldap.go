@@ -14,9 +14,12 @@ func resourceLDAPPropertyMapping() *schema.Resource {
 		ReadContext:   resourceLDAPPropertyMappingRead,
 		UpdateContext: resourceLDAPPropertyMappingUpdate,
 		DeleteContext: resourceLDAPPropertyMappingDelete,
-		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
-		},
+		Timeouts:      defaultTimeouts(),
+		Importer: importStateByKey("pk", map[string]importListFunc{
+			"name": func(ctx context.Context, c *APIClient, name string, page int32) importPage {
+				return importList(c.client.PropertymappingsApi.PropertymappingsLdapList(ctx).Name(name).Page(page).Execute())
+			},
+		}),
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:     schema.TypeString,
@@ -64,10 +67,11 @@ func resourceLDAPPropertyMappingRead(ctx context.Context, d *schema.ResourceData
 
 	res, hr, err := c.client.PropertymappingsApi.PropertymappingsLdapRetrieve(ctx, d.Id()).Execute()
 	if err != nil {
-		return httpToDiag(d, hr, err)
+		return httpOperationToDiag(d, operationRead, hr, err)
 	}
 
 	setWrapper(d, "name", res.Name)
+	setWrapper(d, "managed", res.Managed.Get())
 	setWrapper(d, "expression", res.Expression)
 	setWrapper(d, "object_field", res.ObjectField)
 	return diags
@@ -80,7 +84,7 @@ func resourceLDAPPropertyMappingUpdate(ctx context.Context, d *schema.ResourceDa
 
 	res, hr, err := c.client.PropertymappingsApi.PropertymappingsLdapUpdate(ctx, d.Id()).LDAPPropertyMappingRequest(*app).Execute()
 	if err != nil {
-		return httpToDiag(d, hr, err)
+		return httpOperationToDiag(d, operationUpdate, hr, err)
 	}
 
 	d.SetId(res.Pk)
@@ -91,7 +95,7 @@ func resourceLDAPPropertyMappingDelete(ctx context.Context, d *schema.ResourceDa
 	c := m.(*APIClient)
 	hr, err := c.client.PropertymappingsApi.PropertymappingsLdapDestroy(ctx, d.Id()).Execute()
 	if err != nil {
-		return httpToDiag(d, hr, err)
+		return httpOperationToDiag(d, operationDelete, hr, err)
 	}
 	return diag.Diagnostics{}
 }
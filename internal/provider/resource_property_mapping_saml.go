@@ -14,9 +14,12 @@ func resourceSAMLPropertyMapping() *schema.Resource {
 		ReadContext:   resourceSAMLPropertyMappingRead,
 		UpdateContext: resourceSAMLPropertyMappingUpdate,
 		DeleteContext: resourceSAMLPropertyMappingDelete,
-		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
-		},
+		Timeouts:      defaultTimeouts(),
+		Importer: importStateByKey("pk", map[string]importListFunc{
+			"name": func(ctx context.Context, c *APIClient, name string, page int32) importPage {
+				return importList(c.client.PropertymappingsApi.PropertymappingsSamlList(ctx).Name(name).Page(page).Execute())
+			},
+		}),
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:     schema.TypeString,
@@ -71,10 +74,11 @@ func resourceSAMLPropertyMappingRead(ctx context.Context, d *schema.ResourceData
 
 	res, hr, err := c.client.PropertymappingsApi.PropertymappingsSamlRetrieve(ctx, d.Id()).Execute()
 	if err != nil {
-		return httpToDiag(d, hr, err)
+		return httpOperationToDiag(d, operationRead, hr, err)
 	}
 
 	setWrapper(d, "name", res.Name)
+	setWrapper(d, "managed", res.Managed.Get())
 	setWrapper(d, "expression", res.Expression)
 	setWrapper(d, "saml_name", res.SamlName)
 	if res.FriendlyName.IsSet() {
@@ -90,7 +94,7 @@ func resourceSAMLPropertyMappingUpdate(ctx context.Context, d *schema.ResourceDa
 
 	res, hr, err := c.client.PropertymappingsApi.PropertymappingsSamlUpdate(ctx, d.Id()).SAMLPropertyMappingRequest(*app).Execute()
 	if err != nil {
-		return httpToDiag(d, hr, err)
+		return httpOperationToDiag(d, operationUpdate, hr, err)
 	}
 
 	d.SetId(res.Pk)
@@ -101,7 +105,7 @@ func resourceSAMLPropertyMappingDelete(ctx context.Context, d *schema.ResourceDa
 	c := m.(*APIClient)
 	hr, err := c.client.PropertymappingsApi.PropertymappingsSamlDestroy(ctx, d.Id()).Execute()
 	if err != nil {
-		return httpToDiag(d, hr, err)
+		return httpOperationToDiag(d, operationDelete, hr, err)
 	}
 	return diag.Diagnostics{}
 }
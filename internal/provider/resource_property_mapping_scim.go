@@ -14,9 +14,13 @@ func resourceSCIMPropertyMapping() *schema.Resource {
 		ReadContext:   resourceSCIMPropertyMappingRead,
 		UpdateContext: resourceSCIMPropertyMappingUpdate,
 		DeleteContext: resourceSCIMPropertyMappingDelete,
-		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
-		},
+		Timeouts:      defaultTimeouts(),
+		CustomizeDiff: customizeDiffVersion("2023.3", nil),
+		Importer: importStateByKey("pk", map[string]importListFunc{
+			"name": func(ctx context.Context, c *APIClient, name string, page int32) importPage {
+				return importList(c.client.PropertymappingsApi.PropertymappingsScimList(ctx).Name(name).Page(page).Execute())
+			},
+		}),
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:     schema.TypeString,
@@ -59,10 +63,11 @@ func resourceSCIMPropertyMappingRead(ctx context.Context, d *schema.ResourceData
 
 	res, hr, err := c.client.PropertymappingsApi.PropertymappingsScimRetrieve(ctx, d.Id()).Execute()
 	if err != nil {
-		return httpToDiag(d, hr, err)
+		return httpOperationToDiag(d, operationRead, hr, err)
 	}
 
 	setWrapper(d, "name", res.Name)
+	setWrapper(d, "managed", res.Managed.Get())
 	setWrapper(d, "expression", res.Expression)
 	return diags
 }
@@ -74,7 +79,7 @@ func resourceSCIMPropertyMappingUpdate(ctx context.Context, d *schema.ResourceDa
 
 	res, hr, err := c.client.PropertymappingsApi.PropertymappingsScimUpdate(ctx, d.Id()).SCIMMappingRequest(*app).Execute()
 	if err != nil {
-		return httpToDiag(d, hr, err)
+		return httpOperationToDiag(d, operationUpdate, hr, err)
 	}
 
 	d.SetId(res.Pk)
@@ -85,7 +90,7 @@ func resourceSCIMPropertyMappingDelete(ctx context.Context, d *schema.ResourceDa
 	c := m.(*APIClient)
 	hr, err := c.client.PropertymappingsApi.PropertymappingsScimDestroy(ctx, d.Id()).Execute()
 	if err != nil {
-		return httpToDiag(d, hr, err)
+		return httpOperationToDiag(d, operationDelete, hr, err)
 	}
 	return diag.Diagnostics{}
 }
@@ -15,9 +15,15 @@ func resourceProviderLDAP() *schema.Resource {
 		ReadContext:   resourceProviderLDAPRead,
 		UpdateContext: resourceProviderLDAPUpdate,
 		DeleteContext: resourceProviderLDAPDelete,
-		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
-		},
+		Timeouts:      defaultTimeouts(),
+		CustomizeDiff: customizeDiffVersion("", map[string]string{
+			"mfa_support": "2023.6",
+		}),
+		Importer: importStateByKey("pk", map[string]importListFunc{
+			"name": func(ctx context.Context, c *APIClient, name string, page int32) importPage {
+				return importList(c.client.ProvidersApi.ProvidersLdapList(ctx).NameIexact(name).Page(page).Execute())
+			},
+		}),
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:     schema.TypeString,
@@ -54,14 +60,16 @@ func resourceProviderLDAP() *schema.Resource {
 				Default:  4000,
 			},
 			"search_mode": {
-				Type:     schema.TypeString,
-				Optional: true,
-				Default:  api.LDAPAPIACCESSMODE_DIRECT,
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          api.LDAPAPIACCESSMODE_DIRECT,
+				ValidateDiagFunc: validateEnum(api.AllowedLDAPAPIAccessModeEnumValues),
 			},
 			"bind_mode": {
-				Type:     schema.TypeString,
-				Optional: true,
-				Default:  api.LDAPAPIACCESSMODE_DIRECT,
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          api.LDAPAPIACCESSMODE_DIRECT,
+				ValidateDiagFunc: validateEnum(api.AllowedLDAPAPIAccessModeEnumValues),
 			},
 			"mfa_support": {
 				Type:     schema.TypeBool,
@@ -119,7 +127,7 @@ func resourceProviderLDAPRead(ctx context.Context, d *schema.ResourceData, m int
 	}
 	res, hr, err := c.client.ProvidersApi.ProvidersLdapRetrieve(ctx, int32(id)).Execute()
 	if err != nil {
-		return httpToDiag(d, hr, err)
+		return httpOperationToDiag(d, operationRead, hr, err)
 	}
 
 	setWrapper(d, "name", res.Name)
@@ -150,7 +158,7 @@ func resourceProviderLDAPUpdate(ctx context.Context, d *schema.ResourceData, m i
 
 	res, hr, err := c.client.ProvidersApi.ProvidersLdapUpdate(ctx, int32(id)).LDAPProviderRequest(*app).Execute()
 	if err != nil {
-		return httpToDiag(d, hr, err)
+		return httpOperationToDiag(d, operationUpdate, hr, err)
 	}
 
 	d.SetId(strconv.Itoa(int(res.Pk)))
@@ -165,7 +173,7 @@ func resourceProviderLDAPDelete(ctx context.Context, d *schema.ResourceData, m i
 	}
 	hr, err := c.client.ProvidersApi.ProvidersLdapDestroy(ctx, int32(id)).Execute()
 	if err != nil {
-		return httpToDiag(d, hr, err)
+		return httpOperationToDiag(d, operationDelete, hr, err)
 	}
 	return diag.Diagnostics{}
 }
@@ -16,9 +16,17 @@ func resourceProviderOAuth2() *schema.Resource {
 		ReadContext:   resourceProviderOAuth2Read,
 		UpdateContext: resourceProviderOAuth2Update,
 		DeleteContext: resourceProviderOAuth2Delete,
-		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
-		},
+		Timeouts:      defaultTimeouts(),
+		CustomizeDiff: customizeDiffVersion("", map[string]string{
+			"access_token_validity":  "2023.2",
+			"authentication_flow":    "2023.4",
+			"refresh_token_validity": "2023.2",
+		}),
+		Importer: importStateByKey("pk", map[string]importListFunc{
+			"name": func(ctx context.Context, c *APIClient, name string, page int32) importPage {
+				return importList(c.client.ProvidersApi.ProvidersOauth2List(ctx).Name(name).Page(page).Execute())
+			},
+		}),
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:     schema.TypeString,
@@ -40,9 +48,10 @@ func resourceProviderOAuth2() *schema.Resource {
 				Optional: true,
 			},
 			"client_type": {
-				Type:     schema.TypeString,
-				Optional: true,
-				Default:  api.CLIENTTYPEENUM_CONFIDENTIAL,
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          api.CLIENTTYPEENUM_CONFIDENTIAL,
+				ValidateDiagFunc: validateEnum(api.AllowedClientTypeEnumEnumValues),
 			},
 			"client_id": {
 				Type:     schema.TypeString,
@@ -55,19 +64,25 @@ func resourceProviderOAuth2() *schema.Resource {
 				Computed:  true,
 			},
 			"access_code_validity": {
-				Type:     schema.TypeString,
-				Optional: true,
-				Default:  "minutes=1",
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          "minutes=1",
+				ValidateDiagFunc: validateTimedelta,
+				DiffSuppressFunc: diffSuppressTimedelta,
 			},
 			"access_token_validity": {
-				Type:     schema.TypeString,
-				Optional: true,
-				Default:  "minutes=10",
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          "minutes=10",
+				ValidateDiagFunc: validateTimedelta,
+				DiffSuppressFunc: diffSuppressTimedelta,
 			},
 			"refresh_token_validity": {
-				Type:     schema.TypeString,
-				Optional: true,
-				Default:  "days=30",
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          "days=30",
+				ValidateDiagFunc: validateTimedelta,
+				DiffSuppressFunc: diffSuppressTimedelta,
 			},
 			"include_claims_in_id_token": {
 				Type:     schema.TypeBool,
@@ -86,14 +101,16 @@ func resourceProviderOAuth2() *schema.Resource {
 				},
 			},
 			"sub_mode": {
-				Type:     schema.TypeString,
-				Default:  api.SUBMODEENUM_HASHED_USER_ID,
-				Optional: true,
+				Type:             schema.TypeString,
+				Default:          api.SUBMODEENUM_HASHED_USER_ID,
+				Optional:         true,
+				ValidateDiagFunc: validateEnum(api.AllowedSubModeEnumEnumValues),
 			},
 			"issuer_mode": {
-				Type:     schema.TypeString,
-				Default:  api.ISSUERMODEENUM_PER_PROVIDER,
-				Optional: true,
+				Type:             schema.TypeString,
+				Default:          api.ISSUERMODEENUM_PER_PROVIDER,
+				Optional:         true,
+				ValidateDiagFunc: validateEnum(api.AllowedIssuerModeEnumEnumValues),
 			},
 			"jwks_sources": {
 				Type:     schema.TypeList,
@@ -162,7 +179,7 @@ func resourceProviderOAuth2Read(ctx context.Context, d *schema.ResourceData, m i
 	}
 	res, hr, err := c.client.ProvidersApi.ProvidersOauth2Retrieve(ctx, int32(id)).Execute()
 	if err != nil {
-		return httpToDiag(d, hr, err)
+		return httpOperationToDiag(d, operationRead, hr, err)
 	}
 
 	setWrapper(d, "name", res.Name)
@@ -202,7 +219,7 @@ func resourceProviderOAuth2Update(ctx context.Context, d *schema.ResourceData, m
 
 	res, hr, err := c.client.ProvidersApi.ProvidersOauth2Update(ctx, int32(id)).OAuth2ProviderRequest(*app).Execute()
 	if err != nil {
-		return httpToDiag(d, hr, err)
+		return httpOperationToDiag(d, operationUpdate, hr, err)
 	}
 
 	d.SetId(strconv.Itoa(int(res.Pk)))
@@ -217,7 +234,7 @@ func resourceProviderOAuth2Delete(ctx context.Context, d *schema.ResourceData, m
 	}
 	hr, err := c.client.ProvidersApi.ProvidersOauth2Destroy(ctx, int32(id)).Execute()
 	if err != nil {
-		return httpToDiag(d, hr, err)
+		return httpOperationToDiag(d, operationDelete, hr, err)
 	}
 	return diag.Diagnostics{}
 }
@@ -15,9 +15,17 @@ func resourceProviderProxy() *schema.Resource {
 		ReadContext:   resourceProviderProxyRead,
 		UpdateContext: resourceProviderProxyUpdate,
 		DeleteContext: resourceProviderProxyDelete,
-		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
-		},
+		Timeouts:      defaultTimeouts(),
+		CustomizeDiff: customizeDiffVersion("", map[string]string{
+			"access_token_validity":  "2023.2",
+			"authentication_flow":    "2023.4",
+			"refresh_token_validity": "2023.2",
+		}),
+		Importer: importStateByKey("pk", map[string]importListFunc{
+			"name": func(ctx context.Context, c *APIClient, name string, page int32) importPage {
+				return importList(c.client.ProvidersApi.ProvidersProxyList(ctx).NameIexact(name).Page(page).Execute())
+			},
+		}),
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:     schema.TypeString,
@@ -80,24 +88,29 @@ func resourceProviderProxy() *schema.Resource {
 				Optional: true,
 			},
 			"mode": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Default:     api.PROXYMODE_PROXY,
-				Description: "Valid values are 'proxy', 'forward_single' or 'forward_domain'.",
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          api.PROXYMODE_PROXY,
+				Description:      "Valid values are 'proxy', 'forward_single' or 'forward_domain'.",
+				ValidateDiagFunc: validateEnum(api.AllowedProxyModeEnumValues),
 			},
 			"cookie_domain": {
 				Type:     schema.TypeString,
 				Optional: true,
 			},
 			"access_token_validity": {
-				Type:     schema.TypeString,
-				Optional: true,
-				Default:  "minutes=10",
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          "minutes=10",
+				ValidateDiagFunc: validateTimedelta,
+				DiffSuppressFunc: diffSuppressTimedelta,
 			},
 			"refresh_token_validity": {
-				Type:     schema.TypeString,
-				Optional: true,
-				Default:  "days=30",
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          "days=30",
+				ValidateDiagFunc: validateTimedelta,
+				DiffSuppressFunc: diffSuppressTimedelta,
 			},
 			"jwks_sources": {
 				Type:     schema.TypeList,
@@ -184,7 +197,7 @@ func resourceProviderProxyRead(ctx context.Context, d *schema.ResourceData, m in
 	}
 	res, hr, err := c.client.ProvidersApi.ProvidersProxyRetrieve(ctx, int32(id)).Execute()
 	if err != nil {
-		return httpToDiag(d, hr, err)
+		return httpOperationToDiag(d, operationRead, hr, err)
 	}
 
 	setWrapper(d, "name", res.Name)
@@ -222,7 +235,7 @@ func resourceProviderProxyUpdate(ctx context.Context, d *schema.ResourceData, m
 
 	res, hr, err := c.client.ProvidersApi.ProvidersProxyUpdate(ctx, int32(id)).ProxyProviderRequest(*app).Execute()
 	if err != nil {
-		return httpToDiag(d, hr, err)
+		return httpOperationToDiag(d, operationUpdate, hr, err)
 	}
 
 	d.SetId(strconv.Itoa(int(res.Pk)))
@@ -237,7 +250,7 @@ func resourceProviderProxyDelete(ctx context.Context, d *schema.ResourceData, m
 	}
 	hr, err := c.client.ProvidersApi.ProvidersProxyDestroy(ctx, int32(id)).Execute()
 	if err != nil {
-		return httpToDiag(d, hr, err)
+		return httpOperationToDiag(d, operationDelete, hr, err)
 	}
 	return diag.Diagnostics{}
 }
@@ -15,9 +15,16 @@ func resourceProviderSAML() *schema.Resource {
 		ReadContext:   resourceProviderSAMLRead,
 		UpdateContext: resourceProviderSAMLUpdate,
 		DeleteContext: resourceProviderSAMLDelete,
-		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
-		},
+		Timeouts:      defaultTimeouts(),
+		CustomizeDiff: customizeDiffVersion("", map[string]string{
+			"authentication_flow": "2023.4",
+			"default_relay_state": "2023.8",
+		}),
+		Importer: importStateByKey("pk", map[string]importListFunc{
+			"name": func(ctx context.Context, c *APIClient, name string, page int32) importPage {
+				return importList(c.client.ProvidersApi.ProvidersSamlList(ctx).Name(name).Page(page).Execute())
+			},
+		}),
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:     schema.TypeString,
@@ -80,33 +87,41 @@ func resourceProviderSAML() *schema.Resource {
 				Default:  "authentik",
 			},
 			"assertion_valid_not_before": {
-				Type:     schema.TypeString,
-				Optional: true,
-				Default:  "minutes=-5",
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          "minutes=-5",
+				ValidateDiagFunc: validateTimedelta,
+				DiffSuppressFunc: diffSuppressTimedelta,
 			},
 			"assertion_valid_not_on_or_after": {
-				Type:     schema.TypeString,
-				Optional: true,
-				Default:  "minutes=5",
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          "minutes=5",
+				ValidateDiagFunc: validateTimedelta,
+				DiffSuppressFunc: diffSuppressTimedelta,
 			},
 			"session_valid_not_on_or_after": {
-				Type:     schema.TypeString,
-				Optional: true,
-				Default:  "minutes=86400",
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          "minutes=86400",
+				ValidateDiagFunc: validateTimedelta,
+				DiffSuppressFunc: diffSuppressTimedelta,
 			},
 			"name_id_mapping": {
 				Type:     schema.TypeString,
 				Optional: true,
 			},
 			"digest_algorithm": {
-				Type:     schema.TypeString,
-				Optional: true,
-				Default:  api.DIGESTALGORITHMENUM__2001_04_XMLENCSHA256,
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          api.DIGESTALGORITHMENUM__2001_04_XMLENCSHA256,
+				ValidateDiagFunc: validateEnum(api.AllowedDigestAlgorithmEnumEnumValues),
 			},
 			"signature_algorithm": {
-				Type:     schema.TypeString,
-				Optional: true,
-				Default:  api.SIGNATUREALGORITHMENUM__2001_04_XMLDSIG_MORERSA_SHA256,
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          api.SIGNATUREALGORITHMENUM__2001_04_XMLDSIG_MORERSA_SHA256,
+				ValidateDiagFunc: validateEnum(api.AllowedSignatureAlgorithmEnumEnumValues),
 			},
 			"signing_kp": {
 				Type:     schema.TypeString,
@@ -117,9 +132,10 @@ func resourceProviderSAML() *schema.Resource {
 				Optional: true,
 			},
 			"sp_binding": {
-				Type:     schema.TypeString,
-				Optional: true,
-				Default:  api.SPBINDINGENUM_REDIRECT,
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          api.SPBINDINGENUM_REDIRECT,
+				ValidateDiagFunc: validateEnum(api.AllowedSpBindingEnumEnumValues),
 			},
 			"default_relay_state": {
 				Type:     schema.TypeString,
@@ -187,7 +203,7 @@ func resourceProviderSAMLRead(ctx context.Context, d *schema.ResourceData, m int
 	}
 	res, hr, err := c.client.ProvidersApi.ProvidersSamlRetrieve(ctx, int32(id)).Execute()
 	if err != nil {
-		return httpToDiag(d, hr, err)
+		return httpOperationToDiag(d, operationRead, hr, err)
 	}
 
 	setWrapper(d, "name", res.Name)
@@ -234,7 +250,7 @@ func resourceProviderSAMLUpdate(ctx context.Context, d *schema.ResourceData, m i
 
 	res, hr, err := c.client.ProvidersApi.ProvidersSamlUpdate(ctx, int32(id)).SAMLProviderRequest(*app).Execute()
 	if err != nil {
-		return httpToDiag(d, hr, err)
+		return httpOperationToDiag(d, operationUpdate, hr, err)
 	}
 
 	d.SetId(strconv.Itoa(int(res.Pk)))
@@ -249,7 +265,7 @@ func resourceProviderSAMLDelete(ctx context.Context, d *schema.ResourceData, m i
 	}
 	hr, err := c.client.ProvidersApi.ProvidersSamlDestroy(ctx, int32(id)).Execute()
 	if err != nil {
-		return httpToDiag(d, hr, err)
+		return httpOperationToDiag(d, operationDelete, hr, err)
 	}
 	return diag.Diagnostics{}
 }
@@ -14,9 +14,12 @@ func resourceScopeMapping() *schema.Resource {
 		ReadContext:   resourceScopeMappingRead,
 		UpdateContext: resourceScopeMappingUpdate,
 		DeleteContext: resourceScopeMappingDelete,
-		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
-		},
+		Timeouts:      defaultTimeouts(),
+		Importer: importStateByKey("pk", map[string]importListFunc{
+			"name": func(ctx context.Context, c *APIClient, name string, page int32) importPage {
+				return importList(c.client.PropertymappingsApi.PropertymappingsScopeList(ctx).Name(name).Page(page).Execute())
+			},
+		}),
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:     schema.TypeString,
@@ -71,10 +74,11 @@ func resourceScopeMappingRead(ctx context.Context, d *schema.ResourceData, m int
 
 	res, hr, err := c.client.PropertymappingsApi.PropertymappingsScopeRetrieve(ctx, d.Id()).Execute()
 	if err != nil {
-		return httpToDiag(d, hr, err)
+		return httpOperationToDiag(d, operationRead, hr, err)
 	}
 
 	setWrapper(d, "name", res.Name)
+	setWrapper(d, "managed", res.Managed.Get())
 	setWrapper(d, "expression", res.Expression)
 	setWrapper(d, "scope_name", res.ScopeName)
 	setWrapper(d, "description", res.Description)
@@ -88,7 +92,7 @@ func resourceScopeMappingUpdate(ctx context.Context, d *schema.ResourceData, m i
 
 	res, hr, err := c.client.PropertymappingsApi.PropertymappingsScopeUpdate(ctx, d.Id()).ScopeMappingRequest(*app).Execute()
 	if err != nil {
-		return httpToDiag(d, hr, err)
+		return httpOperationToDiag(d, operationUpdate, hr, err)
 	}
 
 	d.SetId(res.Pk)
@@ -99,7 +103,7 @@ func resourceScopeMappingDelete(ctx context.Context, d *schema.ResourceData, m i
 	c := m.(*APIClient)
 	hr, err := c.client.PropertymappingsApi.PropertymappingsScopeDestroy(ctx, d.Id()).Execute()
 	if err != nil {
-		return httpToDiag(d, hr, err)
+		return httpOperationToDiag(d, operationDelete, hr, err)
 	}
 	return diag.Diagnostics{}
 }
@@ -14,9 +14,12 @@ func resourceSourceLDAP() *schema.Resource {
 		ReadContext:   resourceSourceLDAPRead,
 		UpdateContext: resourceSourceLDAPUpdate,
 		DeleteContext: resourceSourceLDAPDelete,
-		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
-		},
+		Timeouts:      defaultTimeouts(),
+		Importer: importStateByKey("slug", map[string]importListFunc{
+			"slug": func(ctx context.Context, c *APIClient, slug string, page int32) importPage {
+				return importList(c.client.SourcesApi.SourcesLdapList(ctx).Slug(slug).Page(page).Execute())
+			},
+		}),
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:     schema.TypeString,
@@ -184,10 +187,11 @@ func resourceSourceLDAPRead(ctx context.Context, d *schema.ResourceData, m inter
 	c := m.(*APIClient)
 	res, hr, err := c.client.SourcesApi.SourcesLdapRetrieve(ctx, d.Id()).Execute()
 	if err != nil {
-		return httpToDiag(d, hr, err)
+		return httpOperationToDiag(d, operationRead, hr, err)
 	}
 
 	setWrapper(d, "name", res.Name)
+	setWrapper(d, "managed", res.Managed.Get())
 	setWrapper(d, "slug", res.Slug)
 	setWrapper(d, "uuid", res.Pk)
 	setWrapper(d, "enabled", res.Enabled)
@@ -223,7 +227,7 @@ func resourceSourceLDAPUpdate(ctx context.Context, d *schema.ResourceData, m int
 
 	res, hr, err := c.client.SourcesApi.SourcesLdapUpdate(ctx, d.Id()).LDAPSourceRequest(*app).Execute()
 	if err != nil {
-		return httpToDiag(d, hr, err)
+		return httpOperationToDiag(d, operationUpdate, hr, err)
 	}
 
 	d.SetId(res.Slug)
@@ -234,7 +238,7 @@ func resourceSourceLDAPDelete(ctx context.Context, d *schema.ResourceData, m int
 	c := m.(*APIClient)
 	hr, err := c.client.SourcesApi.SourcesLdapDestroy(ctx, d.Id()).Execute()
 	if err != nil {
-		return httpToDiag(d, hr, err)
+		return httpOperationToDiag(d, operationDelete, hr, err)
 	}
 	return diag.Diagnostics{}
 }
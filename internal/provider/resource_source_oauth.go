@@ -16,9 +16,12 @@ func resourceSourceOAuth() *schema.Resource {
 		ReadContext:   resourceSourceOAuthRead,
 		UpdateContext: resourceSourceOAuthUpdate,
 		DeleteContext: resourceSourceOAuthDelete,
-		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
-		},
+		Timeouts:      defaultTimeouts(),
+		Importer: importStateByKey("slug", map[string]importListFunc{
+			"slug": func(ctx context.Context, c *APIClient, slug string, page int32) importPage {
+				return importList(c.client.SourcesApi.SourcesOauthList(ctx).Slug(slug).Page(page).Execute())
+			},
+		}),
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:     schema.TypeString,
@@ -52,19 +55,22 @@ func resourceSourceOAuth() *schema.Resource {
 				Default:  true,
 			},
 			"policy_engine_mode": {
-				Type:     schema.TypeString,
-				Optional: true,
-				Default:  api.POLICYENGINEMODE_ANY,
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          api.POLICYENGINEMODE_ANY,
+				ValidateDiagFunc: validateEnum(api.AllowedPolicyEngineModeEnumValues),
 			},
 			"user_matching_mode": {
-				Type:     schema.TypeString,
-				Optional: true,
-				Default:  api.USERMATCHINGMODEENUM_IDENTIFIER,
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          api.USERMATCHINGMODEENUM_IDENTIFIER,
+				ValidateDiagFunc: validateEnum(api.AllowedUserMatchingModeEnumEnumValues),
 			},
 
 			"provider_type": {
-				Type:     schema.TypeString,
-				Required: true,
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validateEnum(api.AllowedProviderTypeEnumEnumValues),
 			},
 
 			"request_token_url": {
@@ -199,10 +205,11 @@ func resourceSourceOAuthRead(ctx context.Context, d *schema.ResourceData, m inte
 	c := m.(*APIClient)
 	res, hr, err := c.client.SourcesApi.SourcesOauthRetrieve(ctx, d.Id()).Execute()
 	if err != nil {
-		return httpToDiag(d, hr, err)
+		return httpOperationToDiag(d, operationRead, hr, err)
 	}
 
 	setWrapper(d, "name", res.Name)
+	setWrapper(d, "managed", res.Managed.Get())
 	setWrapper(d, "slug", res.Slug)
 	setWrapper(d, "uuid", res.Pk)
 	setWrapper(d, "user_path_template", res.UserPathTemplate)
@@ -251,7 +258,7 @@ func resourceSourceOAuthUpdate(ctx context.Context, d *schema.ResourceData, m in
 
 	res, hr, err := c.client.SourcesApi.SourcesOauthUpdate(ctx, d.Id()).OAuthSourceRequest(*app).Execute()
 	if err != nil {
-		return httpToDiag(d, hr, err)
+		return httpOperationToDiag(d, operationUpdate, hr, err)
 	}
 
 	d.SetId(res.Slug)
@@ -262,7 +269,7 @@ func resourceSourceOAuthDelete(ctx context.Context, d *schema.ResourceData, m in
 	c := m.(*APIClient)
 	hr, err := c.client.SourcesApi.SourcesOauthDestroy(ctx, d.Id()).Execute()
 	if err != nil {
-		return httpToDiag(d, hr, err)
+		return httpOperationToDiag(d, operationDelete, hr, err)
 	}
 	return diag.Diagnostics{}
 }
@@ -14,9 +14,12 @@ func resourceSourcePlex() *schema.Resource {
 		ReadContext:   resourceSourcePlexRead,
 		UpdateContext: resourceSourcePlexUpdate,
 		DeleteContext: resourceSourcePlexDelete,
-		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
-		},
+		Timeouts:      defaultTimeouts(),
+		Importer: importStateByKey("slug", map[string]importListFunc{
+			"slug": func(ctx context.Context, c *APIClient, slug string, page int32) importPage {
+				return importList(c.client.SourcesApi.SourcesPlexList(ctx).Slug(slug).Page(page).Execute())
+			},
+		}),
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:     schema.TypeString,
@@ -50,14 +53,16 @@ func resourceSourcePlex() *schema.Resource {
 				Default:  true,
 			},
 			"policy_engine_mode": {
-				Type:     schema.TypeString,
-				Optional: true,
-				Default:  api.POLICYENGINEMODE_ANY,
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          api.POLICYENGINEMODE_ANY,
+				ValidateDiagFunc: validateEnum(api.AllowedPolicyEngineModeEnumValues),
 			},
 			"user_matching_mode": {
-				Type:     schema.TypeString,
-				Optional: true,
-				Default:  api.USERMATCHINGMODEENUM_IDENTIFIER,
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          api.USERMATCHINGMODEENUM_IDENTIFIER,
+				ValidateDiagFunc: validateEnum(api.AllowedUserMatchingModeEnumEnumValues),
 			},
 
 			"client_id": {
@@ -125,10 +130,11 @@ func resourceSourcePlexRead(ctx context.Context, d *schema.ResourceData, m inter
 	c := m.(*APIClient)
 	res, hr, err := c.client.SourcesApi.SourcesPlexRetrieve(ctx, d.Id()).Execute()
 	if err != nil {
-		return httpToDiag(d, hr, err)
+		return httpOperationToDiag(d, operationRead, hr, err)
 	}
 
 	setWrapper(d, "name", res.Name)
+	setWrapper(d, "managed", res.Managed.Get())
 	setWrapper(d, "slug", res.Slug)
 	setWrapper(d, "uuid", res.Pk)
 	setWrapper(d, "user_path_template", res.UserPathTemplate)
@@ -157,7 +163,7 @@ func resourceSourcePlexUpdate(ctx context.Context, d *schema.ResourceData, m int
 
 	res, hr, err := c.client.SourcesApi.SourcesPlexUpdate(ctx, d.Id()).PlexSourceRequest(*app).Execute()
 	if err != nil {
-		return httpToDiag(d, hr, err)
+		return httpOperationToDiag(d, operationUpdate, hr, err)
 	}
 
 	d.SetId(res.Slug)
@@ -168,7 +174,7 @@ func resourceSourcePlexDelete(ctx context.Context, d *schema.ResourceData, m int
 	c := m.(*APIClient)
 	hr, err := c.client.SourcesApi.SourcesPlexDestroy(ctx, d.Id()).Execute()
 	if err != nil {
-		return httpToDiag(d, hr, err)
+		return httpOperationToDiag(d, operationDelete, hr, err)
 	}
 	return diag.Diagnostics{}
 }
@@ -14,9 +14,12 @@ func resourceSourceSAML() *schema.Resource {
 		ReadContext:   resourceSourceSAMLRead,
 		UpdateContext: resourceSourceSAMLUpdate,
 		DeleteContext: resourceSourceSAMLDelete,
-		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
-		},
+		Timeouts:      defaultTimeouts(),
+		Importer: importStateByKey("slug", map[string]importListFunc{
+			"slug": func(ctx context.Context, c *APIClient, slug string, page int32) importPage {
+				return importList(c.client.SourcesApi.SourcesSamlList(ctx).Slug(slug).Page(page).Execute())
+			},
+		}),
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:     schema.TypeString,
@@ -50,14 +53,16 @@ func resourceSourceSAML() *schema.Resource {
 				Default:  true,
 			},
 			"policy_engine_mode": {
-				Type:     schema.TypeString,
-				Optional: true,
-				Default:  api.POLICYENGINEMODE_ANY,
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          api.POLICYENGINEMODE_ANY,
+				ValidateDiagFunc: validateEnum(api.AllowedPolicyEngineModeEnumValues),
 			},
 			"user_matching_mode": {
-				Type:     schema.TypeString,
-				Optional: true,
-				Default:  api.USERMATCHINGMODEENUM_IDENTIFIER,
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          api.USERMATCHINGMODEENUM_IDENTIFIER,
+				ValidateDiagFunc: validateEnum(api.AllowedUserMatchingModeEnumEnumValues),
 			},
 
 			"pre_authentication_flow": {
@@ -82,33 +87,39 @@ func resourceSourceSAML() *schema.Resource {
 				Default:  false,
 			},
 			"name_id_policy": {
-				Type:     schema.TypeString,
-				Optional: true,
-				Default:  api.NAMEIDPOLICYENUM__2_0NAMEID_FORMATPERSISTENT,
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          api.NAMEIDPOLICYENUM__2_0NAMEID_FORMATPERSISTENT,
+				ValidateDiagFunc: validateEnum(api.AllowedNameIdPolicyEnumEnumValues),
 			},
 			"binding_type": {
-				Type:     schema.TypeString,
-				Optional: true,
-				Default:  api.BINDINGTYPEENUM_REDIRECT,
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          api.BINDINGTYPEENUM_REDIRECT,
+				ValidateDiagFunc: validateEnum(api.AllowedBindingTypeEnumEnumValues),
 			},
 			"signing_kp": {
 				Type:     schema.TypeString,
 				Optional: true,
 			},
 			"digest_algorithm": {
-				Type:     schema.TypeString,
-				Optional: true,
-				Default:  api.DIGESTALGORITHMENUM__2001_04_XMLENCSHA256,
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          api.DIGESTALGORITHMENUM__2001_04_XMLENCSHA256,
+				ValidateDiagFunc: validateEnum(api.AllowedDigestAlgorithmEnumEnumValues),
 			},
 			"signature_algorithm": {
-				Type:     schema.TypeString,
-				Optional: true,
-				Default:  api.SIGNATUREALGORITHMENUM__2001_04_XMLDSIG_MORERSA_SHA256,
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          api.SIGNATUREALGORITHMENUM__2001_04_XMLDSIG_MORERSA_SHA256,
+				ValidateDiagFunc: validateEnum(api.AllowedSignatureAlgorithmEnumEnumValues),
 			},
 			"temporary_user_delete_after": {
-				Type:     schema.TypeString,
-				Optional: true,
-				Default:  "days=1",
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          "days=1",
+				ValidateDiagFunc: validateTimedelta,
+				DiffSuppressFunc: diffSuppressTimedelta,
 			},
 
 			"metadata": {
@@ -172,10 +183,11 @@ func resourceSourceSAMLRead(ctx context.Context, d *schema.ResourceData, m inter
 	c := m.(*APIClient)
 	res, hr, err := c.client.SourcesApi.SourcesSamlRetrieve(ctx, d.Id()).Execute()
 	if err != nil {
-		return httpToDiag(d, hr, err)
+		return httpOperationToDiag(d, operationRead, hr, err)
 	}
 
 	setWrapper(d, "name", res.Name)
+	setWrapper(d, "managed", res.Managed.Get())
 	setWrapper(d, "slug", res.Slug)
 	setWrapper(d, "uuid", res.Pk)
 	setWrapper(d, "user_path_template", res.UserPathTemplate)
@@ -208,7 +220,7 @@ func resourceSourceSAMLRead(ctx context.Context, d *schema.ResourceData, m inter
 
 	meta, hr, err := c.client.SourcesApi.SourcesSamlMetadataRetrieve(ctx, d.Id()).Execute()
 	if err != nil {
-		return httpToDiag(d, hr, err)
+		return httpOperationToDiag(d, operationRead, hr, err)
 	}
 	setWrapper(d, "metadata", meta.Metadata)
 	return diags
@@ -220,7 +232,7 @@ func resourceSourceSAMLUpdate(ctx context.Context, d *schema.ResourceData, m int
 
 	res, hr, err := c.client.SourcesApi.SourcesSamlUpdate(ctx, d.Id()).SAMLSourceRequest(*app).Execute()
 	if err != nil {
-		return httpToDiag(d, hr, err)
+		return httpOperationToDiag(d, operationUpdate, hr, err)
 	}
 
 	d.SetId(res.Slug)
@@ -231,7 +243,7 @@ func resourceSourceSAMLDelete(ctx context.Context, d *schema.ResourceData, m int
 	c := m.(*APIClient)
 	hr, err := c.client.SourcesApi.SourcesSamlDestroy(ctx, d.Id()).Execute()
 	if err != nil {
-		return httpToDiag(d, hr, err)
+		return httpOperationToDiag(d, operationDelete, hr, err)
 	}
 	return diag.Diagnostics{}
 }
@@ -14,9 +14,15 @@ func resourceStageAuthenticatorSms() *schema.Resource {
 		ReadContext:   resourceStageAuthenticatorSmsRead,
 		UpdateContext: resourceStageAuthenticatorSmsUpdate,
 		DeleteContext: resourceStageAuthenticatorSmsDelete,
-		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
-		},
+		Timeouts:      defaultTimeouts(),
+		CustomizeDiff: customizeDiffVersion("", map[string]string{
+			"friendly_name": "2023.4",
+		}),
+		Importer: importStateByKey("pk", map[string]importListFunc{
+			"name": func(ctx context.Context, c *APIClient, name string, page int32) importPage {
+				return importList(c.client.StagesApi.StagesAuthenticatorSmsList(ctx).Name(name).Page(page).Execute())
+			},
+		}),
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:     schema.TypeString,
@@ -31,9 +37,10 @@ func resourceStageAuthenticatorSms() *schema.Resource {
 				Optional: true,
 			},
 			"sms_provider": {
-				Type:     schema.TypeString,
-				Optional: true,
-				Default:  api.PROVIDERENUM_TWILIO,
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          api.PROVIDERENUM_TWILIO,
+				ValidateDiagFunc: validateEnum(api.AllowedProviderEnumEnumValues),
 			},
 			"from_number": {
 				Type:     schema.TypeString,
@@ -50,9 +57,10 @@ func resourceStageAuthenticatorSms() *schema.Resource {
 				Sensitive: true,
 			},
 			"auth_type": {
-				Type:     schema.TypeString,
-				Optional: true,
-				Default:  api.AUTHTYPEENUM_BASIC,
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          api.AUTHTYPEENUM_BASIC,
+				ValidateDiagFunc: validateEnum(api.AllowedAuthTypeEnumEnumValues),
 			},
 			"auth_password": {
 				Type:      schema.TypeString,
@@ -120,7 +128,7 @@ func resourceStageAuthenticatorSmsRead(ctx context.Context, d *schema.ResourceDa
 
 	res, hr, err := c.client.StagesApi.StagesAuthenticatorSmsRetrieve(ctx, d.Id()).Execute()
 	if err != nil {
-		return httpToDiag(d, hr, err)
+		return httpOperationToDiag(d, operationRead, hr, err)
 	}
 
 	setWrapper(d, "name", res.Name)
@@ -146,7 +154,7 @@ func resourceStageAuthenticatorSmsUpdate(ctx context.Context, d *schema.Resource
 
 	res, hr, err := c.client.StagesApi.StagesAuthenticatorSmsUpdate(ctx, d.Id()).AuthenticatorSMSStageRequest(*app).Execute()
 	if err != nil {
-		return httpToDiag(d, hr, err)
+		return httpOperationToDiag(d, operationUpdate, hr, err)
 	}
 
 	d.SetId(res.Pk)
@@ -157,7 +165,7 @@ func resourceStageAuthenticatorSmsDelete(ctx context.Context, d *schema.Resource
 	c := m.(*APIClient)
 	hr, err := c.client.StagesApi.StagesAuthenticatorSmsDestroy(ctx, d.Id()).Execute()
 	if err != nil {
-		return httpToDiag(d, hr, err)
+		return httpOperationToDiag(d, operationDelete, hr, err)
 	}
 	return diag.Diagnostics{}
 }
@@ -14,9 +14,16 @@ func resourceStageAuthenticatorStatic() *schema.Resource {
 		ReadContext:   resourceStageAuthenticatorStaticRead,
 		UpdateContext: resourceStageAuthenticatorStaticUpdate,
 		DeleteContext: resourceStageAuthenticatorStaticDelete,
-		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
-		},
+		Timeouts:      defaultTimeouts(),
+		CustomizeDiff: customizeDiffVersion("", map[string]string{
+			"friendly_name": "2023.4",
+			"token_length":  "2023.8",
+		}),
+		Importer: importStateByKey("pk", map[string]importListFunc{
+			"name": func(ctx context.Context, c *APIClient, name string, page int32) importPage {
+				return importList(c.client.StagesApi.StagesAuthenticatorStaticList(ctx).Name(name).Page(page).Execute())
+			},
+		}),
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:     schema.TypeString,
@@ -80,7 +87,7 @@ func resourceStageAuthenticatorStaticRead(ctx context.Context, d *schema.Resourc
 
 	res, hr, err := c.client.StagesApi.StagesAuthenticatorStaticRetrieve(ctx, d.Id()).Execute()
 	if err != nil {
-		return httpToDiag(d, hr, err)
+		return httpOperationToDiag(d, operationRead, hr, err)
 	}
 
 	setWrapper(d, "name", res.Name)
@@ -100,7 +107,7 @@ func resourceStageAuthenticatorStaticUpdate(ctx context.Context, d *schema.Resou
 
 	res, hr, err := c.client.StagesApi.StagesAuthenticatorStaticUpdate(ctx, d.Id()).AuthenticatorStaticStageRequest(*app).Execute()
 	if err != nil {
-		return httpToDiag(d, hr, err)
+		return httpOperationToDiag(d, operationUpdate, hr, err)
 	}
 
 	d.SetId(res.Pk)
@@ -111,7 +118,7 @@ func resourceStageAuthenticatorStaticDelete(ctx context.Context, d *schema.Resou
 	c := m.(*APIClient)
 	hr, err := c.client.StagesApi.StagesAuthenticatorStaticDestroy(ctx, d.Id()).Execute()
 	if err != nil {
-		return httpToDiag(d, hr, err)
+		return httpOperationToDiag(d, operationDelete, hr, err)
 	}
 	return diag.Diagnostics{}
 }
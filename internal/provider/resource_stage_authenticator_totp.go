@@ -14,9 +14,15 @@ func resourceStageAuthenticatorTOTP() *schema.Resource {
 		ReadContext:   resourceStageAuthenticatorTOTPRead,
 		UpdateContext: resourceStageAuthenticatorTOTPUpdate,
 		DeleteContext: resourceStageAuthenticatorTOTPDelete,
-		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
-		},
+		Timeouts:      defaultTimeouts(),
+		CustomizeDiff: customizeDiffVersion("", map[string]string{
+			"friendly_name": "2023.4",
+		}),
+		Importer: importStateByKey("pk", map[string]importListFunc{
+			"name": func(ctx context.Context, c *APIClient, name string, page int32) importPage {
+				return importList(c.client.StagesApi.StagesAuthenticatorTotpList(ctx).Name(name).Page(page).Execute())
+			},
+		}),
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:     schema.TypeString,
@@ -31,9 +37,10 @@ func resourceStageAuthenticatorTOTP() *schema.Resource {
 				Optional: true,
 			},
 			"digits": {
-				Type:     schema.TypeInt,
-				Optional: true,
-				Default:  6,
+				Type:             schema.TypeInt,
+				Optional:         true,
+				Default:          6,
+				ValidateDiagFunc: validateIntEnum(api.AllowedDigitsEnumEnumValues),
 			},
 		},
 	}
@@ -74,7 +81,7 @@ func resourceStageAuthenticatorTOTPRead(ctx context.Context, d *schema.ResourceD
 
 	res, hr, err := c.client.StagesApi.StagesAuthenticatorTotpRetrieve(ctx, d.Id()).Execute()
 	if err != nil {
-		return httpToDiag(d, hr, err)
+		return httpOperationToDiag(d, operationRead, hr, err)
 	}
 
 	setWrapper(d, "name", res.Name)
@@ -93,7 +100,7 @@ func resourceStageAuthenticatorTOTPUpdate(ctx context.Context, d *schema.Resourc
 
 	res, hr, err := c.client.StagesApi.StagesAuthenticatorTotpUpdate(ctx, d.Id()).AuthenticatorTOTPStageRequest(*app).Execute()
 	if err != nil {
-		return httpToDiag(d, hr, err)
+		return httpOperationToDiag(d, operationUpdate, hr, err)
 	}
 
 	d.SetId(res.Pk)
@@ -104,7 +111,7 @@ func resourceStageAuthenticatorTOTPDelete(ctx context.Context, d *schema.Resourc
 	c := m.(*APIClient)
 	hr, err := c.client.StagesApi.StagesAuthenticatorTotpDestroy(ctx, d.Id()).Execute()
 	if err != nil {
-		return httpToDiag(d, hr, err)
+		return httpOperationToDiag(d, operationDelete, hr, err)
 	}
 	return diag.Diagnostics{}
 }
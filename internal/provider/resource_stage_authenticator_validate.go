@@ -14,23 +14,28 @@ func resourceStageAuthenticatorValidate() *schema.Resource {
 		ReadContext:   resourceStageAuthenticatorValidateRead,
 		UpdateContext: resourceStageAuthenticatorValidateUpdate,
 		DeleteContext: resourceStageAuthenticatorValidateDelete,
-		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
-		},
+		Timeouts:      defaultTimeouts(),
+		Importer: importStateByKey("pk", map[string]importListFunc{
+			"name": func(ctx context.Context, c *APIClient, name string, page int32) importPage {
+				return importList(c.client.StagesApi.StagesAuthenticatorValidateList(ctx).Name(name).Page(page).Execute())
+			},
+		}),
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:     schema.TypeString,
 				Required: true,
 			},
 			"not_configured_action": {
-				Type:     schema.TypeString,
-				Required: true,
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validateEnum(api.AllowedNotConfiguredActionEnumEnumValues),
 			},
 			"device_classes": {
 				Type:     schema.TypeList,
 				Optional: true,
 				Elem: &schema.Schema{
-					Type: schema.TypeString,
+					Type:             schema.TypeString,
+					ValidateDiagFunc: validateEnum(api.AllowedDeviceClassesEnumEnumValues),
 				},
 			},
 			"configuration_stages": {
@@ -41,14 +46,17 @@ func resourceStageAuthenticatorValidate() *schema.Resource {
 				},
 			},
 			"last_auth_threshold": {
-				Type:     schema.TypeString,
-				Optional: true,
-				Default:  "seconds=0",
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          "seconds=0",
+				ValidateDiagFunc: validateTimedelta,
+				DiffSuppressFunc: diffSuppressTimedelta,
 			},
 			"webauthn_user_verification": {
-				Type:     schema.TypeString,
-				Optional: true,
-				Default:  "preferred",
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          "preferred",
+				ValidateDiagFunc: validateEnum(api.AllowedUserVerificationEnumEnumValues),
 			},
 		},
 	}
@@ -98,7 +106,7 @@ func resourceStageAuthenticatorValidateRead(ctx context.Context, d *schema.Resou
 
 	res, hr, err := c.client.StagesApi.StagesAuthenticatorValidateRetrieve(ctx, d.Id()).Execute()
 	if err != nil {
-		return httpToDiag(d, hr, err)
+		return httpOperationToDiag(d, operationRead, hr, err)
 	}
 
 	setWrapper(d, "name", res.Name)
@@ -119,7 +127,7 @@ func resourceStageAuthenticatorValidateUpdate(ctx context.Context, d *schema.Res
 
 	res, hr, err := c.client.StagesApi.StagesAuthenticatorValidateUpdate(ctx, d.Id()).AuthenticatorValidateStageRequest(*app).Execute()
 	if err != nil {
-		return httpToDiag(d, hr, err)
+		return httpOperationToDiag(d, operationUpdate, hr, err)
 	}
 
 	d.SetId(res.Pk)
@@ -130,7 +138,7 @@ func resourceStageAuthenticatorValidateDelete(ctx context.Context, d *schema.Res
 	c := m.(*APIClient)
 	hr, err := c.client.StagesApi.StagesAuthenticatorValidateDestroy(ctx, d.Id()).Execute()
 	if err != nil {
-		return httpToDiag(d, hr, err)
+		return httpOperationToDiag(d, operationDelete, hr, err)
 	}
 	return diag.Diagnostics{}
 }
@@ -14,9 +14,15 @@ func resourceStageAuthenticatorWebAuthn() *schema.Resource {
 		ReadContext:   resourceStageAuthenticatorWebAuthnRead,
 		UpdateContext: resourceStageAuthenticatorWebAuthnUpdate,
 		DeleteContext: resourceStageAuthenticatorWebAuthnDelete,
-		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
-		},
+		Timeouts:      defaultTimeouts(),
+		CustomizeDiff: customizeDiffVersion("", map[string]string{
+			"friendly_name": "2023.4",
+		}),
+		Importer: importStateByKey("pk", map[string]importListFunc{
+			"name": func(ctx context.Context, c *APIClient, name string, page int32) importPage {
+				return importList(c.client.StagesApi.StagesAuthenticatorWebauthnList(ctx).Name(name).Page(page).Execute())
+			},
+		}),
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:     schema.TypeString,
@@ -31,18 +37,21 @@ func resourceStageAuthenticatorWebAuthn() *schema.Resource {
 				Optional: true,
 			},
 			"user_verification": {
-				Type:     schema.TypeString,
-				Optional: true,
-				Default:  "preferred",
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          "preferred",
+				ValidateDiagFunc: validateEnum(api.AllowedUserVerificationEnumEnumValues),
 			},
 			"resident_key_requirement": {
-				Type:     schema.TypeString,
-				Optional: true,
-				Default:  "preferred",
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          "preferred",
+				ValidateDiagFunc: validateEnum(api.AllowedResidentKeyRequirementEnumEnumValues),
 			},
 			"authenticator_attachment": {
-				Type:     schema.TypeString,
-				Optional: true,
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: validateEnum(api.AllowedAuthenticatorAttachmentEnumEnumValues),
 			},
 		},
 	}
@@ -91,7 +100,7 @@ func resourceStageAuthenticatorWebAuthnRead(ctx context.Context, d *schema.Resou
 
 	res, hr, err := c.client.StagesApi.StagesAuthenticatorWebauthnRetrieve(ctx, d.Id()).Execute()
 	if err != nil {
-		return httpToDiag(d, hr, err)
+		return httpOperationToDiag(d, operationRead, hr, err)
 	}
 
 	setWrapper(d, "name", res.Name)
@@ -112,7 +121,7 @@ func resourceStageAuthenticatorWebAuthnUpdate(ctx context.Context, d *schema.Res
 
 	res, hr, err := c.client.StagesApi.StagesAuthenticatorWebauthnUpdate(ctx, d.Id()).AuthenticateWebAuthnStageRequest(*app).Execute()
 	if err != nil {
-		return httpToDiag(d, hr, err)
+		return httpOperationToDiag(d, operationUpdate, hr, err)
 	}
 
 	d.SetId(res.Pk)
@@ -123,7 +132,7 @@ func resourceStageAuthenticatorWebAuthnDelete(ctx context.Context, d *schema.Res
 	c := m.(*APIClient)
 	hr, err := c.client.StagesApi.StagesAuthenticatorWebauthnDestroy(ctx, d.Id()).Execute()
 	if err != nil {
-		return httpToDiag(d, hr, err)
+		return httpOperationToDiag(d, operationDelete, hr, err)
 	}
 	return diag.Diagnostics{}
 }
@@ -14,23 +14,29 @@ func resourceStageConsent() *schema.Resource {
 		ReadContext:   resourceStageConsentRead,
 		UpdateContext: resourceStageConsentUpdate,
 		DeleteContext: resourceStageConsentDelete,
-		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
-		},
+		Timeouts:      defaultTimeouts(),
+		Importer: importStateByKey("pk", map[string]importListFunc{
+			"name": func(ctx context.Context, c *APIClient, name string, page int32) importPage {
+				return importList(c.client.StagesApi.StagesConsentList(ctx).Name(name).Page(page).Execute())
+			},
+		}),
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:     schema.TypeString,
 				Required: true,
 			},
 			"mode": {
-				Type:     schema.TypeString,
-				Optional: true,
-				Default:  api.CONSENTSTAGEMODEENUM_ALWAYS_REQUIRE,
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          api.CONSENTSTAGEMODEENUM_ALWAYS_REQUIRE,
+				ValidateDiagFunc: validateEnum(api.AllowedConsentStageModeEnumEnumValues),
 			},
 			"consent_expire_in": {
-				Type:     schema.TypeString,
-				Optional: true,
-				Default:  "weeks=4",
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          "weeks=4",
+				ValidateDiagFunc: validateTimedelta,
+				DiffSuppressFunc: diffSuppressTimedelta,
 			},
 		},
 	}
@@ -71,7 +77,7 @@ func resourceStageConsentRead(ctx context.Context, d *schema.ResourceData, m int
 
 	res, hr, err := c.client.StagesApi.StagesConsentRetrieve(ctx, d.Id()).Execute()
 	if err != nil {
-		return httpToDiag(d, hr, err)
+		return httpOperationToDiag(d, operationRead, hr, err)
 	}
 
 	setWrapper(d, "name", res.Name)
@@ -87,7 +93,7 @@ func resourceStageConsentUpdate(ctx context.Context, d *schema.ResourceData, m i
 
 	res, hr, err := c.client.StagesApi.StagesConsentUpdate(ctx, d.Id()).ConsentStageRequest(*app).Execute()
 	if err != nil {
-		return httpToDiag(d, hr, err)
+		return httpOperationToDiag(d, operationUpdate, hr, err)
 	}
 
 	d.SetId(res.Pk)
@@ -98,7 +104,7 @@ func resourceStageConsentDelete(ctx context.Context, d *schema.ResourceData, m i
 	c := m.(*APIClient)
 	hr, err := c.client.StagesApi.StagesConsentDestroy(ctx, d.Id()).Execute()
 	if err != nil {
-		return httpToDiag(d, hr, err)
+		return httpOperationToDiag(d, operationDelete, hr, err)
 	}
 	return diag.Diagnostics{}
 }
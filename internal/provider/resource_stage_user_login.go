@@ -14,23 +14,34 @@ func resourceStageUserLogin() *schema.Resource {
 		ReadContext:   resourceStageUserLoginRead,
 		UpdateContext: resourceStageUserLoginUpdate,
 		DeleteContext: resourceStageUserLoginDelete,
-		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
-		},
+		Timeouts:      defaultTimeouts(),
+		CustomizeDiff: customizeDiffVersion("", map[string]string{
+			"remember_me_offset":       "2023.3",
+			"terminate_other_sessions": "2023.3",
+		}),
+		Importer: importStateByKey("pk", map[string]importListFunc{
+			"name": func(ctx context.Context, c *APIClient, name string, page int32) importPage {
+				return importList(c.client.StagesApi.StagesUserLoginList(ctx).Name(name).Page(page).Execute())
+			},
+		}),
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:     schema.TypeString,
 				Required: true,
 			},
 			"session_duration": {
-				Type:     schema.TypeString,
-				Optional: true,
-				Default:  "seconds=0",
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          "seconds=0",
+				ValidateDiagFunc: validateTimedelta,
+				DiffSuppressFunc: diffSuppressTimedelta,
 			},
 			"remember_me_offset": {
-				Type:     schema.TypeString,
-				Optional: true,
-				Default:  "seconds=0",
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          "seconds=0",
+				ValidateDiagFunc: validateTimedelta,
+				DiffSuppressFunc: diffSuppressTimedelta,
 			},
 			"terminate_other_sessions": {
 				Type:     schema.TypeBool,
@@ -71,7 +82,7 @@ func resourceStageUserLoginRead(ctx context.Context, d *schema.ResourceData, m i
 
 	res, hr, err := c.client.StagesApi.StagesUserLoginRetrieve(ctx, d.Id()).Execute()
 	if err != nil {
-		return httpToDiag(d, hr, err)
+		return httpOperationToDiag(d, operationRead, hr, err)
 	}
 
 	setWrapper(d, "name", res.Name)
@@ -88,7 +99,7 @@ func resourceStageUserLoginUpdate(ctx context.Context, d *schema.ResourceData, m
 
 	res, hr, err := c.client.StagesApi.StagesUserLoginUpdate(ctx, d.Id()).UserLoginStageRequest(*app).Execute()
 	if err != nil {
-		return httpToDiag(d, hr, err)
+		return httpOperationToDiag(d, operationUpdate, hr, err)
 	}
 
 	d.SetId(res.Pk)
@@ -99,7 +110,7 @@ func resourceStageUserLoginDelete(ctx context.Context, d *schema.ResourceData, m
 	c := m.(*APIClient)
 	hr, err := c.client.StagesApi.StagesUserLoginDestroy(ctx, d.Id()).Execute()
 	if err != nil {
-		return httpToDiag(d, hr, err)
+		return httpOperationToDiag(d, operationDelete, hr, err)
 	}
 	return diag.Diagnostics{}
 }
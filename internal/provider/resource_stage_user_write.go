@@ -14,9 +14,12 @@ func resourceStageUserWrite() *schema.Resource {
 		ReadContext:   resourceStageUserWriteRead,
 		UpdateContext: resourceStageUserWriteUpdate,
 		DeleteContext: resourceStageUserWriteDelete,
-		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
-		},
+		Timeouts:      defaultTimeouts(),
+		Importer: importStateByKey("pk", map[string]importListFunc{
+			"name": func(ctx context.Context, c *APIClient, name string, page int32) importPage {
+				return importList(c.client.StagesApi.StagesUserWriteList(ctx).Name(name).Page(page).Execute())
+			},
+		}),
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:     schema.TypeString,
@@ -28,9 +31,10 @@ func resourceStageUserWrite() *schema.Resource {
 				Default:  true,
 			},
 			"user_creation_mode": {
-				Type:     schema.TypeString,
-				Default:  string(api.USERCREATIONMODEENUM_CREATE_WHEN_REQUIRED),
-				Optional: true,
+				Type:             schema.TypeString,
+				Default:          string(api.USERCREATIONMODEENUM_CREATE_WHEN_REQUIRED),
+				Optional:         true,
+				ValidateDiagFunc: validateEnum(api.AllowedUserCreationModeEnumEnumValues),
 			},
 			"create_users_group": {
 				Type:     schema.TypeString,
@@ -79,7 +83,7 @@ func resourceStageUserWriteRead(ctx context.Context, d *schema.ResourceData, m i
 
 	res, hr, err := c.client.StagesApi.StagesUserWriteRetrieve(ctx, d.Id()).Execute()
 	if err != nil {
-		return httpToDiag(d, hr, err)
+		return httpOperationToDiag(d, operationRead, hr, err)
 	}
 
 	setWrapper(d, "name", res.Name)
@@ -97,7 +101,7 @@ func resourceStageUserWriteUpdate(ctx context.Context, d *schema.ResourceData, m
 
 	res, hr, err := c.client.StagesApi.StagesUserWriteUpdate(ctx, d.Id()).UserWriteStageRequest(*app).Execute()
 	if err != nil {
-		return httpToDiag(d, hr, err)
+		return httpOperationToDiag(d, operationUpdate, hr, err)
 	}
 
 	d.SetId(res.Pk)
@@ -108,7 +112,7 @@ func resourceStageUserWriteDelete(ctx context.Context, d *schema.ResourceData, m
 	c := m.(*APIClient)
 	hr, err := c.client.StagesApi.StagesUserWriteDestroy(ctx, d.Id()).Execute()
 	if err != nil {
-		return httpToDiag(d, hr, err)
+		return httpOperationToDiag(d, operationDelete, hr, err)
 	}
 	return diag.Diagnostics{}
 }
@@ -2,8 +2,6 @@ package provider
 
 import (
 	"context"
-	"encoding/json"
-	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -16,9 +14,12 @@ func resourceTenant() *schema.Resource {
 		ReadContext:   resourceTenantRead,
 		UpdateContext: resourceTenantUpdate,
 		DeleteContext: resourceTenantDelete,
-		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
-		},
+		Timeouts:      defaultTimeouts(),
+		Importer: importStateByKey("tenant_uuid", map[string]importListFunc{
+			"domain": func(ctx context.Context, c *APIClient, domain string, page int32) importPage {
+				return importList(c.client.CoreApi.CoreTenantsList(ctx).Domain(domain).Page(page).Execute())
+			},
+		}),
 		Schema: map[string]*schema.Schema{
 			"domain": {
 				Type:     schema.TypeString,
@@ -67,9 +68,11 @@ func resourceTenant() *schema.Resource {
 				Optional: true,
 			},
 			"event_retention": {
-				Type:     schema.TypeString,
-				Optional: true,
-				Default:  "days=365",
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          "days=365",
+				ValidateDiagFunc: validateTimedelta,
+				DiffSuppressFunc: diffSuppressTimedelta,
 			},
 			"web_certificate": {
 				Type:     schema.TypeString,
@@ -86,7 +89,7 @@ func resourceTenant() *schema.Resource {
 	}
 }
 
-func resourceTenantSchemaToModel(d *schema.ResourceData) (*api.TenantRequest, diag.Diagnostics) {
+func resourceTenantSchemaToModel(d *schema.ResourceData, c *APIClient) (*api.TenantRequest, diag.Diagnostics) {
 	m := api.TenantRequest{
 		Domain:  d.Get("domain").(string),
 		Default: api.PtrBool(d.Get("default").(bool)),
@@ -146,12 +149,9 @@ func resourceTenantSchemaToModel(d *schema.ResourceData) (*api.TenantRequest, di
 		m.WebCertificate.Set(nil)
 	}
 
-	attr := make(map[string]interface{})
-	if l, ok := d.Get("attributes").(string); ok && l != "" {
-		err := json.NewDecoder(strings.NewReader(l)).Decode(&attr)
-		if err != nil {
-			return nil, diag.FromErr(err)
-		}
+	attr, diags := attributesSchemaToModel(d, c)
+	if diags != nil {
+		return nil, diags
 	}
 	m.Attributes = attr
 	return &m, nil
@@ -160,7 +160,7 @@ func resourceTenantSchemaToModel(d *schema.ResourceData) (*api.TenantRequest, di
 func resourceTenantCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(*APIClient)
 
-	mo, diags := resourceTenantSchemaToModel(d)
+	mo, diags := resourceTenantSchemaToModel(d, c)
 	if diags != nil {
 		return diags
 	}
@@ -180,7 +180,7 @@ func resourceTenantRead(ctx context.Context, d *schema.ResourceData, m interface
 
 	res, hr, err := c.client.CoreApi.CoreTenantsRetrieve(ctx, d.Id()).Execute()
 	if err != nil {
-		return httpToDiag(d, hr, err)
+		return httpOperationToDiag(d, operationRead, hr, err)
 	}
 
 	setWrapper(d, "domain", res.Domain)
@@ -209,25 +209,26 @@ func resourceTenantRead(ctx context.Context, d *schema.ResourceData, m interface
 	if res.WebCertificate.IsSet() {
 		setWrapper(d, "web_certificate", res.WebCertificate.Get())
 	}
-	b, err := json.Marshal(res.Attributes)
-	if err != nil {
-		return diag.FromErr(err)
+	attr, diags := attributesModelToSchema(d, c, res.Attributes)
+	if diags != nil {
+		return diags
 	}
-	setWrapper(d, "attributes", string(b))
+	setWrapper(d, "attributes", attr)
+	diags = append(diags, ownershipWarning(d, c, res.Attributes)...)
 	return diags
 }
 
 func resourceTenantUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(*APIClient)
 
-	obj, diags := resourceTenantSchemaToModel(d)
+	obj, diags := resourceTenantSchemaToModel(d, c)
 	if diags != nil {
 		return diags
 	}
 
 	res, hr, err := c.client.CoreApi.CoreTenantsUpdate(ctx, d.Id()).TenantRequest(*obj).Execute()
 	if err != nil {
-		return httpToDiag(d, hr, err)
+		return httpOperationToDiag(d, operationUpdate, hr, err)
 	}
 
 	d.SetId(res.TenantUuid)
@@ -238,7 +239,7 @@ func resourceTenantDelete(ctx context.Context, d *schema.ResourceData, m interfa
 	c := m.(*APIClient)
 	hr, err := c.client.CoreApi.CoreTenantsDestroy(ctx, d.Id()).Execute()
 	if err != nil {
-		return httpToDiag(d, hr, err)
+		return httpOperationToDiag(d, operationDelete, hr, err)
 	}
 	return diag.Diagnostics{}
 }
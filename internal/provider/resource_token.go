@@ -15,6 +15,7 @@ func resourceToken() *schema.Resource {
 		ReadContext:   resourceTokenRead,
 		UpdateContext: resourceTokenUpdate,
 		DeleteContext: resourceTokenDelete,
+		Timeouts:      defaultTimeouts(),
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -45,9 +46,10 @@ func resourceToken() *schema.Resource {
 				Required: true,
 			},
 			"intent": {
-				Type:     schema.TypeString,
-				Optional: true,
-				Default:  api.INTENTENUM_API,
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          api.INTENTENUM_API,
+				ValidateDiagFunc: validateEnum(api.AllowedIntentEnumEnumValues),
 			},
 			"expires": {
 				Type:     schema.TypeString,
@@ -112,10 +114,11 @@ func resourceTokenRead(ctx context.Context, d *schema.ResourceData, m interface{
 
 	res, hr, err := c.client.CoreApi.CoreTokensRetrieve(ctx, d.Id()).Execute()
 	if err != nil {
-		return httpToDiag(d, hr, err)
+		return httpOperationToDiag(d, operationRead, hr, err)
 	}
 
 	setWrapper(d, "identifier", res.Identifier)
+	setWrapper(d, "managed", res.Managed.Get())
 	setWrapper(d, "user", res.User)
 	setWrapper(d, "description", res.Description)
 	setWrapper(d, "intent", res.Intent)
@@ -123,7 +126,7 @@ func resourceTokenRead(ctx context.Context, d *schema.ResourceData, m interface{
 	if rt, ok := d.Get("retrieve_key").(bool); ok && rt {
 		res, hr, err := c.client.CoreApi.CoreTokensViewKeyRetrieve(ctx, d.Id()).Execute()
 		if err != nil {
-			return httpToDiag(d, hr, err)
+			return httpOperationToDiag(d, operationRead, hr, err)
 		}
 		setWrapper(d, "key", res.Key)
 	}
@@ -139,7 +142,7 @@ func resourceTokenUpdate(ctx context.Context, d *schema.ResourceData, m interfac
 	}
 	res, hr, err := c.client.CoreApi.CoreTokensUpdate(ctx, d.Id()).TokenRequest(*app).Execute()
 	if err != nil {
-		return httpToDiag(d, hr, err)
+		return httpOperationToDiag(d, operationUpdate, hr, err)
 	}
 
 	d.SetId(res.Identifier)
@@ -150,7 +153,7 @@ func resourceTokenDelete(ctx context.Context, d *schema.ResourceData, m interfac
 	c := m.(*APIClient)
 	hr, err := c.client.CoreApi.CoreTokensDestroy(ctx, d.Id()).Execute()
 	if err != nil {
-		return httpToDiag(d, hr, err)
+		return httpOperationToDiag(d, operationDelete, hr, err)
 	}
 	return diag.Diagnostics{}
 }
@@ -2,12 +2,11 @@ package provider
 
 import (
 	"context"
-	"encoding/json"
 	"strconv"
-	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	api "goauthentik.io/api/v3"
 )
 
@@ -17,9 +16,15 @@ func resourceUser() *schema.Resource {
 		ReadContext:   resourceUserRead,
 		UpdateContext: resourceUserUpdate,
 		DeleteContext: resourceUserDelete,
-		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
-		},
+		Timeouts:      defaultTimeouts(),
+		CustomizeDiff: customizeDiffVersion("", map[string]string{
+			"type": "2023.8",
+		}),
+		Importer: importStateByKey("pk", map[string]importListFunc{
+			"username": func(ctx context.Context, c *APIClient, username string, page int32) importPage {
+				return importList(c.client.CoreApi.CoreUsersList(ctx).Username(username).Page(page).Execute())
+			},
+		}),
 		Schema: map[string]*schema.Schema{
 			"username": {
 				Type:     schema.TypeString,
@@ -31,9 +36,10 @@ func resourceUser() *schema.Resource {
 				Optional: true,
 			},
 			"type": {
-				Type:     schema.TypeString,
-				Default:  api.USERTYPEENUM_INTERNAL,
-				Optional: true,
+				Type:             schema.TypeString,
+				Default:          api.USERTYPEENUM_INTERNAL,
+				Optional:         true,
+				ValidateDiagFunc: validateEnum(api.AllowedUserTypeEnumEnumValues),
 			},
 			"password": {
 				Type:        schema.TypeString,
@@ -70,6 +76,19 @@ func resourceUser() *schema.Resource {
 				Description:      "JSON format expected. Use jsonencode() to pass objects.",
 				DiffSuppressFunc: diffSuppressJSON,
 			},
+			"attributes_mode": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          attributesModeAuthoritative,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{attributesModeAuthoritative, attributesModeMerge}, false)),
+				Description:      "With `authoritative`, all attributes of the user are replaced with `attributes`. With `merge`, only the keys set in `attributes` and `managed_attribute_keys` are read and written, and keys set by authentik, flows or administrators are preserved.",
+			},
+			"managed_attribute_keys": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Keys which are managed by this resource when `attributes_mode` is `merge`, in addition to the keys set in `attributes`. Managed keys which aren't set in `attributes` are removed from the user.",
+			},
 		},
 	}
 }
@@ -89,12 +108,9 @@ func resourceUserSchemaToModel(d *schema.ResourceData, c *APIClient) (*api.UserR
 
 	m.Groups = castSlice[string](d.Get("groups").([]interface{}))
 
-	attr := make(map[string]interface{})
-	if l, ok := d.Get("attributes").(string); ok && l != "" {
-		err := json.NewDecoder(strings.NewReader(l)).Decode(&attr)
-		if err != nil {
-			return nil, diag.FromErr(err)
-		}
+	attr, diags := attributesSchemaToModel(d, c)
+	if diags != nil {
+		return nil, diags
 	}
 	m.Attributes = attr
 	return &m, nil
@@ -155,7 +171,7 @@ func resourceUserRead(ctx context.Context, d *schema.ResourceData, m interface{}
 
 	res, hr, err := c.client.CoreApi.CoreUsersRetrieve(ctx, int32(id)).Execute()
 	if err != nil {
-		return httpToDiag(d, hr, err)
+		return httpOperationToDiag(d, operationRead, hr, err)
 	}
 
 	setWrapper(d, "name", res.Name)
@@ -164,11 +180,16 @@ func resourceUserRead(ctx context.Context, d *schema.ResourceData, m interface{}
 	setWrapper(d, "email", res.Email)
 	setWrapper(d, "is_active", res.IsActive)
 	setWrapper(d, "path", res.Path)
-	b, err := json.Marshal(res.Attributes)
-	if err != nil {
-		return diag.FromErr(err)
+	attr, diags := attributesModelToSchema(d, c, res.Attributes)
+	if diags != nil {
+		return diags
 	}
-	setWrapper(d, "attributes", string(b))
+	setWrapper(d, "attributes", attr)
+	// State written before `attributes_mode` existed doesn't have it, which would show up as a diff
+	if d.Get("attributes_mode").(string) == "" {
+		setWrapper(d, "attributes_mode", attributesModeAuthoritative)
+	}
+	diags = append(diags, ownershipWarning(d, c, res.Attributes)...)
 	localGroups := castSlice[string](d.Get("groups").([]interface{}))
 	setWrapper(d, "groups", listConsistentMerge(localGroups, res.Groups))
 	return diags
@@ -185,9 +206,16 @@ func resourceUserUpdate(ctx context.Context, d *schema.ResourceData, m interface
 	if err != nil {
 		return diag.FromErr(err)
 	}
+	if d.Get("attributes_mode").(string) == attributesModeMerge {
+		cur, hr, err := c.client.CoreApi.CoreUsersRetrieve(ctx, int32(id)).Execute()
+		if err != nil {
+			return httpOperationToDiag(d, operationUpdate, hr, err)
+		}
+		app.Attributes = attributesMerge(d, c, app.Attributes, cur.Attributes)
+	}
 	res, hr, err := c.client.CoreApi.CoreUsersUpdate(ctx, int32(id)).UserRequest(*app).Execute()
 	if err != nil {
-		return httpToDiag(d, hr, err)
+		return httpOperationToDiag(d, operationUpdate, hr, err)
 	}
 
 	d.SetId(strconv.Itoa(int(res.Pk)))
@@ -207,7 +235,7 @@ func resourceUserDelete(ctx context.Context, d *schema.ResourceData, m interface
 	}
 	hr, err := c.client.CoreApi.CoreUsersDestroy(ctx, int32(id)).Execute()
 	if err != nil {
-		return httpToDiag(d, hr, err)
+		return httpOperationToDiag(d, operationDelete, hr, err)
 	}
 	return diag.Diagnostics{}
 }
@@ -0,0 +1,181 @@
+package provider
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// retryWaitFloor Shortest wait before the first retry, used when no minimum wait is configured, so
+// that the wait still grows exponentially
+const retryWaitFloor = 100 * time.Millisecond
+
+// retryTransport Transport that retries failed requests with jittered exponential backoff
+type retryTransport struct {
+	inner      http.RoundTripper
+	maxRetries int
+	waitMin    time.Duration
+	waitMax    time.Duration
+}
+
+// NewRetryTransport Get a HTTP Transport that retries rate-limited requests, server errors and
+// connection failures up to maxRetries times, waiting between waitMin and waitMax between attempts.
+func NewRetryTransport(inner http.RoundTripper, maxRetries int, waitMin time.Duration, waitMax time.Duration) *retryTransport {
+	if waitMin <= 0 {
+		waitMin = retryWaitFloor
+	}
+	return &retryTransport{
+		inner:      inner,
+		maxRetries: maxRetries,
+		waitMin:    waitMin,
+		waitMax:    waitMax,
+	}
+}
+
+// RoundTrip HTTP Transport
+func (rt *retryTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	getBody, err := rewindableBody(r)
+	if err != nil {
+		return nil, err
+	}
+	for attempt := 0; ; attempt++ {
+		body, err := getBody()
+		if err != nil {
+			return nil, err
+		}
+		req := r.Clone(r.Context())
+		req.Body = body
+		res, err := rt.inner.RoundTrip(req)
+		if attempt >= rt.maxRetries || !shouldRetry(r, res, err) {
+			return res, err
+		}
+		wait := rt.backoff(attempt, res)
+		fields := map[string]interface{}{
+			"http_method": r.Method,
+			"http_path":   r.URL.Path,
+			"attempt":     attempt + 1,
+			"wait":        wait.String(),
+		}
+		if err != nil {
+			fields["error"] = err.Error()
+			tflog.Debug(r.Context(), "authentik: retrying API request after error", fields)
+		} else {
+			fields["http_status_code"] = res.StatusCode
+			tflog.Debug(r.Context(), "authentik: retrying API request after status", fields)
+			// Drain the body so the connection can be re-used
+			_, _ = io.Copy(io.Discard, res.Body)
+			_ = res.Body.Close()
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-r.Context().Done():
+			timer.Stop()
+			return nil, r.Context().Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// backoff Calculate how long to wait before the next attempt, preferring the server's Retry-After header
+func (rt *retryTransport) backoff(attempt int, res *http.Response) time.Duration {
+	if res != nil {
+		if wait, ok := retryAfter(res); ok {
+			if wait > rt.waitMax {
+				return rt.waitMax
+			}
+			return wait
+		}
+	}
+	wait := rt.waitMin << attempt
+	if wait > rt.waitMax || wait <= 0 {
+		wait = rt.waitMax
+	}
+	// Equal jitter, so that parallel requests don't retry in lockstep
+	half := int64(wait / 2)
+	if half <= 0 {
+		return wait
+	}
+	return time.Duration(half + rand.Int63n(half))
+}
+
+// retryAfter Parse the Retry-After header, which can either be a number of seconds or a HTTP date
+func retryAfter(res *http.Response) (time.Duration, bool) {
+	header := res.Header.Get("Retry-After")
+	if header == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(header); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(header); err == nil {
+		wait := time.Until(date)
+		if wait < 0 {
+			wait = 0
+		}
+		return wait, true
+	}
+	return 0, false
+}
+
+// shouldRetry Check if a request can safely be retried. Idempotent requests are retried on
+// server errors and connection failures, other requests only when the server certainly
+// didn't process them.
+func shouldRetry(r *http.Request, res *http.Response, err error) bool {
+	if r.Context().Err() != nil {
+		return false
+	}
+	idempotent := isIdempotent(r)
+	if err != nil {
+		if idempotent {
+			return true
+		}
+		// The connection was never established, so the request can't have been processed
+		var opErr *net.OpError
+		return errors.As(err, &opErr) && opErr.Op == "dial" || errors.Is(err, syscall.ECONNREFUSED)
+	}
+	switch res.StatusCode {
+	case http.StatusTooManyRequests:
+		return true
+	case http.StatusInternalServerError,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout:
+		return idempotent
+	}
+	return false
+}
+
+func isIdempotent(r *http.Request) bool {
+	switch r.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// rewindableBody Return a function that returns a fresh copy of the request body for every attempt
+func rewindableBody(r *http.Request) (func() (io.ReadCloser, error), error) {
+	if r.Body == nil || r.Body == http.NoBody {
+		return func() (io.ReadCloser, error) { return r.Body, nil }, nil
+	}
+	if r.GetBody != nil {
+		_ = r.Body.Close()
+		return r.GetBody, nil
+	}
+	buf, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, err
+	}
+	_ = r.Body.Close()
+	return func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(buf)), nil
+	}, nil
+}
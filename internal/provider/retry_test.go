@@ -0,0 +1,137 @@
+package provider
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testRetryServer(statuses ...int) (*httptest.Server, *int32) {
+	calls := int32(0)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&calls, 1)
+		body, _ := io.ReadAll(r.Body)
+		status := http.StatusOK
+		if int(n) <= len(statuses) {
+			status = statuses[n-1]
+		}
+		w.WriteHeader(status)
+		_, _ = w.Write(body)
+	}))
+	return srv, &calls
+}
+
+func testRetryClient() *http.Client {
+	return &http.Client{
+		Transport: NewRetryTransport(http.DefaultTransport, 3, time.Millisecond, 5*time.Millisecond),
+	}
+}
+
+func Test_retryTransport_RetriesIdempotent(t *testing.T) {
+	srv, calls := testRetryServer(http.StatusBadGateway, http.StatusServiceUnavailable)
+	defer srv.Close()
+
+	req, _ := http.NewRequest(http.MethodPut, srv.URL, strings.NewReader("foo"))
+	res, err := testRetryClient().Do(req)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Equal(t, int32(3), atomic.LoadInt32(calls))
+	// The body has to be replayed on every attempt
+	body, _ := io.ReadAll(res.Body)
+	assert.Equal(t, "foo", string(body))
+}
+
+func Test_retryTransport_NoRetryPostServerError(t *testing.T) {
+	srv, calls := testRetryServer(http.StatusBadGateway)
+	defer srv.Close()
+
+	res, err := testRetryClient().Post(srv.URL, "application/json", strings.NewReader("{}"))
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadGateway, res.StatusCode)
+	assert.Equal(t, int32(1), atomic.LoadInt32(calls))
+}
+
+func Test_retryTransport_RetryPostTooManyRequests(t *testing.T) {
+	srv, calls := testRetryServer(http.StatusTooManyRequests)
+	defer srv.Close()
+
+	res, err := testRetryClient().Post(srv.URL, "application/json", strings.NewReader("{}"))
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Equal(t, int32(2), atomic.LoadInt32(calls))
+}
+
+func Test_retryTransport_MaxRetries(t *testing.T) {
+	srv, calls := testRetryServer(500, 500, 500, 500, 500, 500)
+	defer srv.Close()
+
+	res, err := testRetryClient().Get(srv.URL)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusInternalServerError, res.StatusCode)
+	assert.Equal(t, int32(4), atomic.LoadInt32(calls))
+}
+
+func Test_retryTransport_NoRetryClientError(t *testing.T) {
+	srv, calls := testRetryServer(http.StatusBadRequest)
+	defer srv.Close()
+
+	res, err := testRetryClient().Get(srv.URL)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+	assert.Equal(t, int32(1), atomic.LoadInt32(calls))
+}
+
+func Test_retryTransport_ConnectionRefused(t *testing.T) {
+	srv, _ := testRetryServer()
+	url := srv.URL
+	srv.Close()
+
+	_, err := testRetryClient().Post(url, "application/json", strings.NewReader("{}"))
+	assert.Error(t, err)
+}
+
+func Test_retryAfter(t *testing.T) {
+	res := &http.Response{Header: http.Header{}}
+	_, ok := retryAfter(res)
+	assert.False(t, ok)
+
+	res.Header.Set("Retry-After", "3")
+	wait, ok := retryAfter(res)
+	assert.True(t, ok)
+	assert.Equal(t, 3*time.Second, wait)
+
+	res.Header.Set("Retry-After", time.Now().Add(time.Hour).UTC().Format(http.TimeFormat))
+	wait, ok = retryAfter(res)
+	assert.True(t, ok)
+	assert.Greater(t, wait, 59*time.Minute)
+
+	res.Header.Set("Retry-After", "foo")
+	_, ok = retryAfter(res)
+	assert.False(t, ok)
+}
+
+func Test_retryTransport_backoff(t *testing.T) {
+	rt := NewRetryTransport(nil, 3, time.Second, 10*time.Second)
+	for attempt := 0; attempt < 10; attempt++ {
+		wait := rt.backoff(attempt, nil)
+		assert.LessOrEqual(t, wait, 10*time.Second)
+		assert.GreaterOrEqual(t, wait, 500*time.Millisecond)
+	}
+
+	res := &http.Response{Header: http.Header{}}
+	res.Header.Set("Retry-After", "120")
+	assert.Equal(t, 10*time.Second, rt.backoff(0, res))
+	res.Header.Set("Retry-After", "2")
+	assert.Equal(t, 2*time.Second, rt.backoff(0, res))
+
+	// Without a minimum wait, the wait still grows from a short floor instead of jumping to the maximum
+	rt = NewRetryTransport(nil, 3, 0, 10*time.Second)
+	assert.LessOrEqual(t, rt.backoff(0, nil), retryWaitFloor)
+	assert.LessOrEqual(t, rt.backoff(2, nil), 4*retryWaitFloor)
+}
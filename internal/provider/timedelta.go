@@ -0,0 +1,96 @@
+package provider
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// timedeltaUnits Keyword arguments of python's datetime.timedelta, which authentik builds durations from
+var timedeltaUnits = map[string]time.Duration{
+	"weeks":        7 * 24 * time.Hour,
+	"days":         24 * time.Hour,
+	"hours":        time.Hour,
+	"minutes":      time.Minute,
+	"seconds":      time.Second,
+	"milliseconds": time.Millisecond,
+	"microseconds": time.Microsecond,
+}
+
+// parseTimedelta Parse a duration in authentik's `key=value;key=value` syntax, like `hours=1;minutes=30`.
+// Like authentik, keys are case-insensitive, values can be negative or fractional, and when a key is
+// set multiple times the last value is used.
+func parseTimedelta(s string) (time.Duration, error) {
+	if strings.TrimSpace(s) == "" {
+		return 0, fmt.Errorf("duration is empty")
+	}
+	values := map[string]float64{}
+	for _, pair := range strings.Split(s, ";") {
+		key, value, ok := strings.Cut(pair, "=")
+		if !ok {
+			return 0, fmt.Errorf("invalid duration %q, expected `key=value`", pair)
+		}
+		key = strings.ToLower(strings.TrimSpace(key))
+		if _, ok := timedeltaUnits[key]; !ok {
+			return 0, fmt.Errorf("invalid duration unit %q, expected one of weeks, days, hours, minutes, seconds, milliseconds or microseconds", key)
+		}
+		v, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil || math.IsInf(v, 0) || math.IsNaN(v) {
+			return 0, fmt.Errorf("invalid duration value %q for %s, expected a number", strings.TrimSpace(value), key)
+		}
+		values[key] = v
+	}
+	total := 0.0
+	for key, v := range values {
+		total += v * float64(timedeltaUnits[key])
+	}
+	if math.Abs(total) > math.MaxInt64 {
+		return 0, fmt.Errorf("duration %q is too long", s)
+	}
+	return time.Duration(math.Round(total)), nil
+}
+
+// validateTimedelta Plan-time validation of durations in authentik's `key=value;key=value` syntax
+func validateTimedelta(i interface{}, path cty.Path) diag.Diagnostics {
+	v, ok := i.(string)
+	if !ok {
+		return diag.Diagnostics{
+			{
+				Severity:      diag.Error,
+				Summary:       "Expected a string",
+				AttributePath: path,
+			},
+		}
+	}
+	if _, err := parseTimedelta(v); err != nil {
+		return diag.Diagnostics{
+			{
+				Severity:      diag.Error,
+				Summary:       fmt.Sprintf("Invalid duration %q", v),
+				Detail:        fmt.Sprintf("%s. Durations are set like `hours=1;minutes=30`.", err.Error()),
+				AttributePath: path,
+			},
+		}
+	}
+	return nil
+}
+
+// diffSuppressTimedelta Diff suppression for durations, so that equivalent spellings like `hours=1`
+// and `minutes=60` don't cause a diff
+func diffSuppressTimedelta(k, old, new string, d *schema.ResourceData) bool {
+	o, err := parseTimedelta(old)
+	if err != nil {
+		return false
+	}
+	n, err := parseTimedelta(new)
+	if err != nil {
+		return false
+	}
+	return o == n
+}
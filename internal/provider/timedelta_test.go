@@ -0,0 +1,46 @@
+package provider
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_parseTimedelta(t *testing.T) {
+	for input, expected := range map[string]time.Duration{
+		"minutes=1":                      time.Minute,
+		"minutes=-5":                     -5 * time.Minute,
+		"days=30":                        30 * 24 * time.Hour,
+		"weeks=4":                        4 * 7 * 24 * time.Hour,
+		"seconds=0":                      0,
+		"hours=1;minutes=30":             90 * time.Minute,
+		" Hours = 1.5 ":                  90 * time.Minute,
+		"hours=1;hours=2":                2 * time.Hour,
+		"milliseconds=5;microseconds=10": 5*time.Millisecond + 10*time.Microsecond,
+	} {
+		d, err := parseTimedelta(input)
+		assert.NoError(t, err, input)
+		assert.Equal(t, expected, d, input)
+	}
+	for _, input := range []string{"", "minutes", "minutes=", "minutes=foo", "years=1", "hours=1;", "hours=inf", "weeks=1000000"} {
+		_, err := parseTimedelta(input)
+		assert.Error(t, err, input)
+	}
+}
+
+func Test_validateTimedelta(t *testing.T) {
+	assert.Nil(t, validateTimedelta("hours=1", cty.GetAttrPath("foo")))
+	diags := validateTimedelta("hour=1", cty.GetAttrPath("foo"))
+	assert.True(t, diags.HasError())
+	assert.Equal(t, `Invalid duration "hour=1"`, diags[0].Summary)
+	assert.Equal(t, cty.GetAttrPath("foo"), diags[0].AttributePath)
+}
+
+func Test_diffSuppressTimedelta(t *testing.T) {
+	assert.True(t, diffSuppressTimedelta("", "hours=1", "minutes=60", nil))
+	assert.True(t, diffSuppressTimedelta("", "days=1", "hours=12;hours=24", nil))
+	assert.False(t, diffSuppressTimedelta("", "hours=1", "minutes=61", nil))
+	assert.False(t, diffSuppressTimedelta("", "hours=1", "hour=1", nil))
+}
@@ -0,0 +1,94 @@
+package provider
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+
+	httptransport "github.com/go-openapi/runtime/client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// tlsClientOptions Build the TLS options from the provider configuration
+func tlsClientOptions(d *schema.ResourceData) (httptransport.TLSClientOptions, error) {
+	opts := httptransport.TLSClientOptions{
+		InsecureSkipVerify: d.Get("insecure").(bool),
+		ServerName:         d.Get("tls_server_name").(string),
+	}
+
+	caPEM := d.Get("ca_cert_pem").(string)
+	caFile := d.Get("ca_cert_file").(string)
+	if caPEM != "" && caFile != "" {
+		return opts, errors.New("only one of `ca_cert_pem` and `ca_cert_file` can be set")
+	}
+	if caFile != "" {
+		raw, err := os.ReadFile(caFile)
+		if err != nil {
+			return opts, fmt.Errorf("failed to read CA certificate file: %w", err)
+		}
+		caPEM = string(raw)
+	}
+	if caPEM != "" {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM([]byte(caPEM)) {
+			return opts, errors.New("no valid PEM certificates found in CA certificate")
+		}
+		opts.LoadedCAPool = pool
+	}
+
+	certPEM := d.Get("client_cert_pem").(string)
+	keyPEM := d.Get("client_key_pem").(string)
+	if (certPEM == "") != (keyPEM == "") {
+		return opts, errors.New("`client_cert_pem` and `client_key_pem` must be set together")
+	}
+	if certPEM != "" {
+		cert, err := parseCertificatePEM([]byte(certPEM))
+		if err != nil {
+			return opts, fmt.Errorf("failed to parse client certificate: %w", err)
+		}
+		key, err := parsePrivateKeyPEM([]byte(keyPEM))
+		if err != nil {
+			return opts, fmt.Errorf("failed to parse client key: %w", err)
+		}
+		opts.LoadedCertificate = cert
+		opts.LoadedKey = key
+	}
+	return opts, nil
+}
+
+func parseCertificatePEM(raw []byte) (*x509.Certificate, error) {
+	block, _ := pem.Decode(raw)
+	if block == nil || block.Type != "CERTIFICATE" {
+		return nil, errors.New("no PEM certificate found")
+	}
+	return x509.ParseCertificate(block.Bytes)
+}
+
+// parsePrivateKeyPEM Parse a PKCS#1, PKCS#8 or SEC 1 encoded private key. Only RSA and ECDSA keys
+// are supported, as those are the only types accepted by httptransport.TLSClientOptions
+func parsePrivateKeyPEM(raw []byte) (crypto.PrivateKey, error) {
+	block, _ := pem.Decode(raw)
+	if block == nil {
+		return nil, errors.New("no PEM private key found")
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	if key, err := x509.ParseECPrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	switch key.(type) {
+	case *rsa.PrivateKey, *ecdsa.PrivateKey:
+		return key, nil
+	}
+	return nil, fmt.Errorf("unsupported private key type %T", key)
+}
@@ -0,0 +1,129 @@
+package provider
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func testClientCertificate(t *testing.T) (string, string, *x509.Certificate) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "terraform"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	raw, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	assert.NoError(t, err)
+	cert, err := x509.ParseCertificate(raw)
+	assert.NoError(t, err)
+	keyRaw, err := x509.MarshalPKCS8PrivateKey(key)
+	assert.NoError(t, err)
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: raw})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyRaw})
+	return string(certPEM), string(keyPEM), cert
+}
+
+func testTLSResourceData(t *testing.T, raw map[string]interface{}) *schema.ResourceData {
+	return schema.TestResourceDataRaw(t, Provider("test", false).Schema, raw)
+}
+
+func Test_tlsClientOptions_MutualTLS(t *testing.T) {
+	certPEM, keyPEM, clientCert := testClientCertificate(t)
+	clientCAs := x509.NewCertPool()
+	clientCAs.AddCert(clientCert)
+
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	srv.TLS = &tls.Config{
+		ClientAuth: tls.RequireAndVerifyClientCert,
+		ClientCAs:  clientCAs,
+	}
+	srv.StartTLS()
+	defer srv.Close()
+	serverCAPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw})
+
+	opts, err := tlsClientOptions(testTLSResourceData(t, map[string]interface{}{
+		"ca_cert_pem":     string(serverCAPEM),
+		"client_cert_pem": certPEM,
+		"client_key_pem":  keyPEM,
+		"tls_server_name": "example.com",
+	}))
+	assert.NoError(t, err)
+	transport, err := GetTLSTransport(opts)
+	assert.NoError(t, err)
+
+	res, err := (&http.Client{Transport: transport}).Get(srv.URL)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNoContent, res.StatusCode)
+
+	// Without a client certificate the handshake must fail
+	opts, err = tlsClientOptions(testTLSResourceData(t, map[string]interface{}{
+		"ca_cert_pem": string(serverCAPEM),
+	}))
+	assert.NoError(t, err)
+	transport, err = GetTLSTransport(opts)
+	assert.NoError(t, err)
+	_, err = (&http.Client{Transport: transport}).Get(srv.URL)
+	assert.Error(t, err)
+}
+
+func Test_tlsClientOptions_CAFile(t *testing.T) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	err := os.WriteFile(caFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw}), 0o600)
+	assert.NoError(t, err)
+
+	opts, err := tlsClientOptions(testTLSResourceData(t, map[string]interface{}{
+		"ca_cert_file":    caFile,
+		"tls_server_name": "example.com",
+	}))
+	assert.NoError(t, err)
+	transport, err := GetTLSTransport(opts)
+	assert.NoError(t, err)
+	res, err := (&http.Client{Transport: transport}).Get(srv.URL)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNoContent, res.StatusCode)
+}
+
+func Test_tlsClientOptions_Invalid(t *testing.T) {
+	certPEM, _, _ := testClientCertificate(t)
+
+	_, err := tlsClientOptions(testTLSResourceData(t, map[string]interface{}{
+		"ca_cert_pem": "foo",
+	}))
+	assert.Error(t, err)
+
+	_, err = tlsClientOptions(testTLSResourceData(t, map[string]interface{}{
+		"ca_cert_pem":  certPEM,
+		"ca_cert_file": "/ca.pem",
+	}))
+	assert.Error(t, err)
+
+	_, err = tlsClientOptions(testTLSResourceData(t, map[string]interface{}{
+		"client_cert_pem": certPEM,
+	}))
+	assert.Error(t, err)
+}
@@ -2,50 +2,242 @@ package provider
 
 import (
 	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
 
 	"github.com/getsentry/sentry-go"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+	api "goauthentik.io/api/v3"
 )
 
-func tr(resource func() *schema.Resource) *schema.Resource {
+const (
+	telemetryBackendSentry = "sentry"
+	telemetryBackendOTLP   = "otlp"
+
+	tracerName = "goauthentik.io/terraform-provider-authentik"
+)
+
+// contextFunc Signature of the CRUD functions of resources and data sources
+type contextFunc func(ctx context.Context, rd *schema.ResourceData, m interface{}) diag.Diagnostics
+
+// traced Wrap a CRUD function in a span tagged with the resource type, operation and resource ID.
+// Spans are started with both Sentry and OpenTelemetry, each of which is a no-op unless the
+// matching telemetry backend is configured in the provider.
+func traced(kind string, resourceType string, operation string, inner contextFunc) contextFunc {
+	return func(ctx context.Context, rd *schema.ResourceData, m interface{}) diag.Diagnostics {
+		op := fmt.Sprintf("terraform.%s.%s", kind, operation)
+		sentrySpan := sentry.StartSpan(ctx, op, sentry.WithTransactionName(fmt.Sprintf("terraform.%s", kind)))
+		sentrySpan.Description = fmt.Sprintf("%s %s", resourceType, operation)
+		sentrySpan.SetTag("resource_type", resourceType)
+		sentrySpan.SetTag("operation", operation)
+		defer sentrySpan.Finish()
+
+		ctx, span := otel.Tracer(tracerName).Start(sentrySpan.Context(), op, trace.WithAttributes(
+			attribute.String("authentik.resource_type", resourceType),
+			attribute.String("authentik.operation", operation),
+		))
+		defer span.End()
+
+		diags := inner(ctx, rd, m)
+
+		// The ID is only known after the operation for create and data sources
+		sentrySpan.SetTag("resource_id", rd.Id())
+		span.SetAttributes(attribute.String("authentik.resource_id", rd.Id()))
+		if diags.HasError() {
+			sentrySpan.Status = sentry.SpanStatusInternalError
+			for _, d := range diags {
+				if d.Severity == diag.Error {
+					span.SetStatus(codes.Error, d.Summary)
+					break
+				}
+			}
+		}
+		return diags
+	}
+}
+
+// FlushTelemetry Export all finished spans when the provider shuts down. Spans are otherwise exported
+// in batches in the background, so CRUD operations don't wait for the telemetry backend.
+func FlushTelemetry() {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	if tp, ok := otel.GetTracerProvider().(*sdktrace.TracerProvider); ok {
+		_ = tp.Shutdown(ctx)
+	}
+	sentry.Flush(2 * time.Second)
+}
+
+func tr(resourceType string, resource func() *schema.Resource) *schema.Resource {
 	sc := resource()
 	so := resource()
-	sc.CreateContext = func(ctx context.Context, rd *schema.ResourceData, m interface{}) diag.Diagnostics {
-		span := sentry.StartSpan(ctx, "terraform.resource.create", sentry.WithTransactionName("terraform.resource"))
-		span.Description = "Resource create"
-		defer span.Finish()
-		return so.CreateContext(ctx, rd, m)
-	}
-	sc.ReadContext = func(ctx context.Context, rd *schema.ResourceData, m interface{}) diag.Diagnostics {
-		span := sentry.StartSpan(ctx, "terraform.resource.read", sentry.WithTransactionName("terraform.resource"))
-		span.Description = "Resource read"
-		defer span.Finish()
-		return so.ReadContext(ctx, rd, m)
-	}
-	sc.UpdateContext = func(ctx context.Context, rd *schema.ResourceData, m interface{}) diag.Diagnostics {
-		span := sentry.StartSpan(ctx, "terraform.resource.update", sentry.WithTransactionName("terraform.resource"))
-		span.Description = "Resource update"
-		defer span.Finish()
-		return so.UpdateContext(ctx, rd, m)
-	}
-	sc.DeleteContext = func(ctx context.Context, rd *schema.ResourceData, m interface{}) diag.Diagnostics {
-		span := sentry.StartSpan(ctx, "terraform.resource.delete", sentry.WithTransactionName("terraform.resource"))
-		span.Description = "Resource delete"
-		defer span.Finish()
-		return so.DeleteContext(ctx, rd, m)
-	}
+	sc.CreateContext = schema.CreateContextFunc(traced("resource", resourceType, "create", contextFunc(so.CreateContext)))
+	sc.ReadContext = schema.ReadContextFunc(traced("resource", resourceType, "read", contextFunc(so.ReadContext)))
+	sc.UpdateContext = schema.UpdateContextFunc(traced("resource", resourceType, "update", contextFunc(so.UpdateContext)))
+	sc.DeleteContext = schema.DeleteContextFunc(traced("resource", resourceType, "delete", contextFunc(so.DeleteContext)))
 	return sc
 }
 
-func td(resource func() *schema.Resource) *schema.Resource {
+func td(resourceType string, resource func() *schema.Resource) *schema.Resource {
 	sc := resource()
 	so := resource()
-	sc.ReadContext = func(ctx context.Context, rd *schema.ResourceData, m interface{}) diag.Diagnostics {
-		span := sentry.StartSpan(ctx, "terraform.datasource.read", sentry.WithTransactionName("terraform.datasource"))
-		span.Description = "Datasource read"
-		defer span.Finish()
-		return so.ReadContext(ctx, rd, m)
-	}
+	sc.ReadContext = schema.ReadContextFunc(traced("datasource", resourceType, "read", contextFunc(so.ReadContext)))
 	return sc
 }
+
+// tracedResources Wrap every resource constructor in tr
+func tracedResources(resources map[string]func() *schema.Resource) map[string]*schema.Resource {
+	m := make(map[string]*schema.Resource, len(resources))
+	for name, resource := range resources {
+		m[name] = tr(name, resource)
+	}
+	return m
+}
+
+// tracedDataSources Wrap every data source constructor in td
+func tracedDataSources(dataSources map[string]func() *schema.Resource) map[string]*schema.Resource {
+	m := make(map[string]*schema.Resource, len(dataSources))
+	for name, dataSource := range dataSources {
+		m[name] = td(name, dataSource)
+	}
+	return m
+}
+
+// configureTelemetry Set up the telemetry backend configured in the provider's `telemetry` block.
+// Telemetry is only sent when it was explicitly enabled.
+func configureTelemetry(ctx context.Context, d *schema.ResourceData, version string, apiClient *api.APIClient) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	block := d.Get("telemetry").([]interface{})
+	if len(block) < 1 || block[0] == nil {
+		return false, diags
+	}
+	t := block[0].(map[string]interface{})
+	if !t["enabled"].(bool) {
+		return false, diags
+	}
+	sampleRate := t["sample_rate"].(float64)
+
+	switch t["backend"].(string) {
+	case telemetryBackendSentry:
+		dsn := t["sentry_dsn"].(string)
+		if envDsn, found := os.LookupEnv("SENTRY_DSN"); found && dsn == "" {
+			dsn = envDsn
+		}
+		environment := ""
+		rootConfig, _, err := apiClient.RootApi.RootConfigRetrieve(ctx).Execute()
+		if err == nil {
+			// Fall back to the DSN configured in authentik
+			if dsn == "" {
+				dsn = rootConfig.ErrorReporting.SentryDsn
+			}
+			environment = rootConfig.ErrorReporting.Environment
+		}
+		if dsn == "" {
+			return false, append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  "Telemetry disabled",
+				Detail:   "The sentry telemetry backend is enabled, but no DSN is configured in `sentry_dsn`, `SENTRY_DSN` or authentik.",
+			})
+		}
+		err = sentry.Init(sentry.ClientOptions{
+			Dsn:              dsn,
+			EnableTracing:    true,
+			Environment:      environment,
+			TracesSampleRate: sampleRate,
+			Release:          fmt.Sprintf("terraform-provider-authentik@%s", version),
+		})
+		if err != nil {
+			return false, append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  "Failed to initialise Sentry",
+				Detail:   err.Error(),
+			})
+		}
+	case telemetryBackendOTLP:
+		opts := []otlptracehttp.Option{}
+		// Without an endpoint, the standard OTEL_EXPORTER_OTLP_* environment variables are used
+		if endpoint := t["otlp_endpoint"].(string); endpoint != "" {
+			u, err := url.Parse(endpoint)
+			if err != nil {
+				return false, diag.FromErr(err)
+			}
+			opts = append(opts, otlptracehttp.WithEndpoint(u.Host))
+			if u.Scheme == "http" {
+				opts = append(opts, otlptracehttp.WithInsecure())
+			}
+			if u.Path != "" && u.Path != "/" {
+				opts = append(opts, otlptracehttp.WithURLPath(u.Path))
+			}
+		}
+		if headers := t["otlp_headers"].(map[string]interface{}); len(headers) > 0 {
+			h := map[string]string{}
+			for k, v := range headers {
+				h[k] = v.(string)
+			}
+			opts = append(opts, otlptracehttp.WithHeaders(h))
+		}
+		exporter, err := otlptracehttp.New(ctx, opts...)
+		if err != nil {
+			return false, diag.FromErr(err)
+		}
+		otel.SetTracerProvider(sdktrace.NewTracerProvider(
+			sdktrace.WithBatcher(exporter),
+			sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(sampleRate))),
+			sdktrace.WithResource(resource.NewSchemaless(
+				attribute.String("service.name", "terraform-provider-authentik"),
+				attribute.String("service.version", version),
+			)),
+		))
+		otel.SetTextMapPropagator(propagation.TraceContext{})
+	default:
+		return false, diag.Errorf("unknown telemetry backend %s, expected one of `%s` or `%s`", t["backend"].(string), telemetryBackendOTLP, telemetryBackendSentry)
+	}
+	return true, diags
+}
+
+type tracingTransport struct {
+	inner http.RoundTripper
+}
+
+// NewTracingTransport Get a HTTP transport which starts a span for every request, as a child of the
+// span in the request's context
+func NewTracingTransport(inner http.RoundTripper) *tracingTransport {
+	return &tracingTransport{inner}
+}
+
+func (tt *tracingTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	// A RoundTripper must not modify the caller's request
+	r = r.Clone(r.Context())
+	span := sentry.StartSpan(r.Context(), "authentik.go.http_request")
+	r.Header.Set("sentry-trace", span.ToSentryTrace())
+	span.Description = fmt.Sprintf("%s %s", r.Method, r.URL.String())
+	span.SetTag("url", r.URL.String())
+	span.SetTag("method", r.Method)
+	defer span.Finish()
+
+	ctx, otelSpan := otel.Tracer(tracerName).Start(r.Context(), "authentik.go.http_request", trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(
+		attribute.String("http.method", r.Method),
+		attribute.String("http.url", r.URL.String()),
+	))
+	defer otelSpan.End()
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(r.Header))
+
+	res, err := tt.inner.RoundTrip(r.WithContext(span.Context()))
+	if err != nil {
+		otelSpan.SetStatus(codes.Error, err.Error())
+	} else {
+		otelSpan.SetAttributes(attribute.Int("http.status_code", res.StatusCode))
+	}
+	return res, err
+}
@@ -0,0 +1,107 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func testTracingResource() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+			d.SetId("foo")
+			return nil
+		},
+		ReadContext: func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+			return diag.Errorf("read failed")
+		},
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+		},
+	}
+}
+
+func Test_tr(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	defer otel.SetTracerProvider(previous)
+
+	r := tr("authentik_test", testTracingResource)
+	d := r.TestResourceData()
+	diags := r.CreateContext(context.Background(), d, nil)
+	assert.False(t, diags.HasError())
+	diags = r.ReadContext(context.Background(), d, nil)
+	assert.True(t, diags.HasError())
+
+	spans := recorder.Ended()
+	assert.Len(t, spans, 2)
+	assert.Equal(t, "terraform.resource.create", spans[0].Name())
+	assert.ElementsMatch(t, []attribute.KeyValue{
+		attribute.String("authentik.resource_type", "authentik_test"),
+		attribute.String("authentik.operation", "create"),
+		attribute.String("authentik.resource_id", "foo"),
+	}, spans[0].Attributes())
+	assert.Equal(t, "terraform.resource.read", spans[1].Name())
+	assert.Equal(t, codes.Error, spans[1].Status().Code)
+	assert.Equal(t, "read failed", spans[1].Status().Description)
+}
+
+func Test_configureTelemetry_Disabled(t *testing.T) {
+	enabled, diags := configureTelemetry(context.Background(), testTLSResourceData(t, map[string]interface{}{}), "test", nil)
+	assert.False(t, enabled)
+	assert.False(t, diags.HasError())
+
+	enabled, diags = configureTelemetry(context.Background(), testTLSResourceData(t, map[string]interface{}{
+		"telemetry": []interface{}{
+			map[string]interface{}{
+				"backend": "sentry",
+			},
+		},
+	}), "test", nil)
+	assert.False(t, enabled)
+	assert.False(t, diags.HasError())
+}
+
+func Test_tracingTransport(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	defer otel.SetTracerProvider(previous)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	ctx, parent := otel.Tracer(tracerName).Start(context.Background(), "parent")
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL, nil)
+	assert.NoError(t, err)
+	res, err := NewTracingTransport(http.DefaultTransport).RoundTrip(req)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNoContent, res.StatusCode)
+	parent.End()
+	// The trace headers are only set on the request that was sent
+	assert.Empty(t, req.Header.Get("sentry-trace"))
+	assert.Empty(t, req.Header.Get("traceparent"))
+
+	spans := recorder.Ended()
+	assert.Len(t, spans, 2)
+	assert.Equal(t, "authentik.go.http_request", spans[0].Name())
+	// The request span is a child of the span in the request's context
+	assert.Equal(t, parent.SpanContext().SpanID(), spans[0].Parent().SpanID())
+}
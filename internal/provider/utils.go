@@ -3,17 +3,33 @@ package provider
 import (
 	"bytes"
 	"encoding/json"
+	"fmt"
 	"io"
-	"log"
 	"net/http"
 	"reflect"
 	"sort"
 	"strings"
+	"time"
 
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
+// defaultTimeout Timeout of resource operations, unless configured otherwise in the `timeouts` block
+const defaultTimeout = 5 * time.Minute
+
+// defaultTimeouts Timeouts of all resources, which are applied to the context of each operation
+func defaultTimeouts() *schema.ResourceTimeout {
+	return &schema.ResourceTimeout{
+		Create: schema.DefaultTimeout(defaultTimeout),
+		Read:   schema.DefaultTimeout(defaultTimeout),
+		Update: schema.DefaultTimeout(defaultTimeout),
+		Delete: schema.DefaultTimeout(defaultTimeout),
+	}
+}
+
 func setWrapper(d *schema.ResourceData, key string, data interface{}) {
 	err := d.Set(key, data)
 	if err != nil {
@@ -26,7 +42,8 @@ func diffSuppressExpression(k, old, new string, d *schema.ResourceData) bool {
 	return strings.TrimSuffix(new, "\n") == old
 }
 
-// diffSuppressJSON Diff suppression for JSON objects
+// diffSuppressJSON Diff suppression for JSON objects. The provider's default attributes are left out
+// of the state by attributesModelToSchema, so only keys set on the resource are compared.
 func diffSuppressJSON(k, old, new string, d *schema.ResourceData) bool {
 	var j, j2 interface{}
 	if err := json.Unmarshal([]byte(old), &j); err != nil {
@@ -106,15 +123,169 @@ func httpToDiag(d *schema.ResourceData, r *http.Response, err error) diag.Diagno
 	if r == nil {
 		return diag.Errorf("HTTP Error '%s' without http response", err.Error())
 	}
-	if r.StatusCode == 404 {
-		d.SetId("")
-		return diag.Diagnostics{}
-	}
 	buff := &bytes.Buffer{}
 	_, er := io.Copy(buff, r.Body)
 	if er != nil {
-		log.Printf("[DEBUG] authentik: failed to read response: %s", er.Error())
+		tflog.Warn(r.Request.Context(), "authentik: failed to read error response", map[string]interface{}{
+			"error": er.Error(),
+		})
+	}
+	if r.StatusCode == http.StatusBadRequest {
+		if diags := validationErrorToDiag(d, r, buff.Bytes()); len(diags) > 0 {
+			return diags
+		}
 	}
-	log.Printf("[DEBUG] authentik: error response: %s", buff.String())
 	return diag.Errorf("HTTP Error '%s' during request '%s %s': \"%s\"", err.Error(), r.Request.Method, r.Request.URL.Path, buff.String())
 }
+
+// crudOperation Operation of a resource during which a request was sent
+type crudOperation string
+
+const (
+	operationRead   crudOperation = "read"
+	operationUpdate crudOperation = "update"
+	operationDelete crudOperation = "delete"
+)
+
+// httpOperationToDiag Like httpToDiag, but handles a 404 for the resource's own object depending on
+// the operation that caused it. Only use it for requests for the object of d, a 404 of any other
+// request, like for a referenced object, is a regular error.
+func httpOperationToDiag(d *schema.ResourceData, op crudOperation, r *http.Response, err error) diag.Diagnostics {
+	if r != nil && r.StatusCode == http.StatusNotFound && d.Id() != "" {
+		return notFoundToDiag(d, op, r)
+	}
+	return httpToDiag(d, r, err)
+}
+
+// notFoundToDiag Handle a 404 for the object of d
+func notFoundToDiag(d *schema.ResourceData, op crudOperation, r *http.Response) diag.Diagnostics {
+	switch op {
+	case operationDelete:
+		// The object is already gone, which is what we wanted
+		d.SetId("")
+		return diag.Diagnostics{}
+	case operationUpdate:
+		return diag.Diagnostics{
+			{
+				Severity: diag.Error,
+				Summary:  "Object was deleted outside of Terraform",
+				Detail:   fmt.Sprintf("The object with ID %s could not be updated as it no longer exists in authentik (%s %s). Run `terraform apply` again to re-create it.", d.Id(), r.Request.Method, r.Request.URL.Path),
+			},
+		}
+	}
+	id := d.Id()
+	d.SetId("")
+	return diag.Diagnostics{
+		{
+			Severity: diag.Warning,
+			Summary:  "Object not found, removing from state",
+			Detail:   fmt.Sprintf("The object with ID %s was not found in authentik (%s %s), it was most likely deleted outside of Terraform.", id, r.Request.Method, r.Request.URL.Path),
+		},
+	}
+}
+
+// apiFieldAliases API fields mapped to the schema keys of the attributes which are sent as that field, for
+// attributes which are named differently than their API field
+var apiFieldAliases = map[string][]string{
+	"authorization_flow":        {"bind_flow"},
+	"basic_auth_user_attribute": {"basic_auth_username_attribute"},
+	"provider":                  {"protocol_provider", "sms_provider"},
+	"providers":                 {"protocol_providers"},
+}
+
+// schemaKeyForField Find the schema key of the attribute an API field was set from
+func schemaKeyForField(d *schema.ResourceData, field string) (string, bool) {
+	if d == nil {
+		return "", false
+	}
+	for _, key := range append([]string{field}, apiFieldAliases[field]...) {
+		// Get returns nil for keys that aren't in the schema
+		if d.Get(key) != nil {
+			return key, true
+		}
+	}
+	return "", false
+}
+
+// validationError Single error message of a validation error response
+type validationError struct {
+	field   string
+	path    string
+	message string
+}
+
+// flattenValidationErrors Flatten a validation error response like `{"field": ["message"], "config": {"key": ["message"]}}`
+func flattenValidationErrors(field string, path string, v interface{}) []validationError {
+	errs := []validationError{}
+	switch e := v.(type) {
+	case string:
+		errs = append(errs, validationError{field: field, path: path, message: e})
+	case []interface{}:
+		for idx, ee := range e {
+			// List fields return an error object for each item, with empty objects for valid items
+			if _, ok := ee.(string); ok {
+				errs = append(errs, flattenValidationErrors(field, path, ee)...)
+			} else {
+				errs = append(errs, flattenValidationErrors(field, fmt.Sprintf("%s[%d]", path, idx), ee)...)
+			}
+		}
+	case map[string]interface{}:
+		keys := make([]string, 0, len(e))
+		for k := range e {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			if field == "" {
+				errs = append(errs, flattenValidationErrors(k, k, e[k])...)
+			} else {
+				errs = append(errs, flattenValidationErrors(field, fmt.Sprintf("%s.%s", path, k), e[k])...)
+			}
+		}
+	}
+	return errs
+}
+
+// validationErrorToDiag Convert a JSON error response from authentik into a diagnostic per error,
+// each scoped to the attribute it refers to where possible. Returns nil if the body isn't a JSON object.
+func validationErrorToDiag(d *schema.ResourceData, r *http.Response, body []byte) diag.Diagnostics {
+	var raw map[string]interface{}
+	if err := json.Unmarshal(body, &raw); err != nil || len(raw) < 1 {
+		return nil
+	}
+	request := fmt.Sprintf("HTTP %d during request '%s %s'", r.StatusCode, r.Request.Method, r.Request.URL.Path)
+	diags := diag.Diagnostics{}
+	for _, e := range flattenValidationErrors("", "", raw) {
+		switch e.field {
+		case "non_field_errors", "detail":
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  e.message,
+				Detail:   fmt.Sprintf("authentik rejected the request (%s)", request),
+			})
+			continue
+		}
+		de := diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  e.message,
+		}
+		if key, ok := schemaKeyForField(d, e.field); ok {
+			de.AttributePath = cty.GetAttrPath(key)
+			de.Detail = fmt.Sprintf("authentik rejected the value of `%s` (%s)", strings.Replace(e.path, e.field, key, 1), request)
+		} else {
+			de.Detail = fmt.Sprintf("authentik rejected the value of `%s` (%s)", e.path, request)
+		}
+		diags = append(diags, de)
+	}
+	return diags
+}
+
+// sortedKeys Get the keys of a map in sorted order
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
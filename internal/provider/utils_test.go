@@ -1,9 +1,21 @@
 package provider
 
 import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/stretchr/testify/assert"
+	api "goauthentik.io/api/v3"
 )
 
 func Test_castSlice(t *testing.T) {
@@ -11,3 +23,252 @@ func Test_castSlice(t *testing.T) {
 	bar := castSlice[string](foo)
 	assert.Equal(t, bar, []string{"test"})
 }
+
+func testErrorResponse(status int, body string) *http.Response {
+	return &http.Response{
+		StatusCode: status,
+		Body:       io.NopCloser(strings.NewReader(body)),
+		Request: &http.Request{
+			Method: http.MethodPost,
+			URL:    &url.URL{Path: "/api/v3/providers/ldap/"},
+		},
+	}
+}
+
+func Test_httpToDiag_Validation(t *testing.T) {
+	d := resourceProviderLDAP().TestResourceData()
+	diags := httpToDiag(d, testErrorResponse(400, `{
+		"name": ["This field may not be blank."],
+		"authorization_flow": ["Invalid pk \"foo\" - object does not exist."],
+		"non_field_errors": ["Something else went wrong."]
+	}`), errors.New("400 Bad Request"))
+	assert.Len(t, diags, 3)
+	assert.Equal(t, "Invalid pk \"foo\" - object does not exist.", diags[0].Summary)
+	assert.Equal(t, cty.GetAttrPath("bind_flow"), diags[0].AttributePath)
+	assert.Contains(t, diags[0].Detail, "`bind_flow`")
+	assert.Equal(t, "This field may not be blank.", diags[1].Summary)
+	assert.Equal(t, cty.GetAttrPath("name"), diags[1].AttributePath)
+	assert.Equal(t, "Something else went wrong.", diags[2].Summary)
+	assert.Nil(t, diags[2].AttributePath)
+}
+
+func Test_httpToDiag_Nested(t *testing.T) {
+	d := resourceOutpost().TestResourceData()
+	diags := httpToDiag(d, testErrorResponse(400, `{
+		"config": {"authentik_host": ["Enter a valid URL."]},
+		"unknown": ["Not a schema key."]
+	}`), errors.New("400 Bad Request"))
+	assert.Len(t, diags, 2)
+	assert.Equal(t, cty.GetAttrPath("config"), diags[0].AttributePath)
+	assert.Contains(t, diags[0].Detail, "`config.authentik_host`")
+	assert.Nil(t, diags[1].AttributePath)
+	assert.Contains(t, diags[1].Detail, "`unknown`")
+}
+
+func Test_httpToDiag_Fallback(t *testing.T) {
+	d := resourceProviderLDAP().TestResourceData()
+	diags := httpToDiag(d, testErrorResponse(500, "Internal Server Error"), errors.New("500 Internal Server Error"))
+	assert.Len(t, diags, 1)
+	assert.Equal(t, "HTTP Error '500 Internal Server Error' during request 'POST /api/v3/providers/ldap/': \"Internal Server Error\"", diags[0].Summary)
+
+	// Responses which couldn't be decoded aren't treated as validation errors
+	diags = httpToDiag(d, testErrorResponse(201, `{"name": "foo"}`), errors.New("invalid value"))
+	assert.Len(t, diags, 1)
+	assert.Equal(t, "HTTP Error 'invalid value' during request 'POST /api/v3/providers/ldap/': \"{\"name\": \"foo\"}\"", diags[0].Summary)
+
+	diags = httpToDiag(nil, nil, errors.New("connection refused"))
+	assert.Len(t, diags, 1)
+	assert.Contains(t, diags[0].Summary, "connection refused")
+}
+
+func Test_httpToDiag_NotValidation(t *testing.T) {
+	d := resourceProviderLDAP().TestResourceData()
+	// Only 400 responses contain validation errors
+	diags := httpToDiag(d, testErrorResponse(403, `{"detail": "You do not have permission to perform this action."}`), errors.New("403 Forbidden"))
+	assert.Len(t, diags, 1)
+	assert.Nil(t, diags[0].AttributePath)
+	assert.Equal(t, "HTTP Error '403 Forbidden' during request 'POST /api/v3/providers/ldap/': \"{\"detail\": \"You do not have permission to perform this action.\"}\"", diags[0].Summary)
+
+	diags = httpToDiag(d, testErrorResponse(500, `{"name": ["Something went wrong."]}`), errors.New("500 Internal Server Error"))
+	assert.Len(t, diags, 1)
+	assert.Nil(t, diags[0].AttributePath)
+	assert.Contains(t, diags[0].Summary, "HTTP Error '500 Internal Server Error'")
+}
+
+func Test_flattenValidationErrors(t *testing.T) {
+	errs := flattenValidationErrors("", "", map[string]interface{}{
+		"property_mappings": []interface{}{
+			map[string]interface{}{},
+			map[string]interface{}{"expression": []interface{}{"Invalid expression."}},
+		},
+	})
+	assert.Equal(t, []validationError{
+		{field: "property_mappings", path: "property_mappings[1].expression", message: "Invalid expression."},
+	}, errs)
+}
+
+func testAPIClient(t *testing.T, handler http.Handler) *APIClient {
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+	u, err := url.Parse(srv.URL)
+	assert.NoError(t, err)
+	config := api.NewConfiguration()
+	config.Host = u.Host
+	config.Scheme = u.Scheme
+	return &APIClient{client: api.NewAPIClient(config)}
+}
+
+func Test_httpToDiag_NotFound(t *testing.T) {
+	notFound := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"detail": "Not found."}`))
+	})
+	c := testAPIClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// The outpost's update also fetches the default settings, which still exist
+		if strings.HasSuffix(r.URL.Path, "/outposts/instances/default_settings/") {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"config": {}}`))
+			return
+		}
+		notFound(w, r)
+	}))
+	resources := map[string]struct {
+		resource func() *schema.Resource
+		id       string
+		raw      map[string]interface{}
+	}{
+		"group": {
+			resource: resourceGroup,
+			id:       "a0b1c2d3-e4f5-4a6b-8c7d-8e9f0a1b2c3d",
+			raw:      map[string]interface{}{"name": "foo"},
+		},
+		"application": {
+			resource: resourceApplication,
+			id:       "foo",
+			raw:      map[string]interface{}{"name": "foo", "slug": "foo"},
+		},
+		"policy_expression": {
+			resource: resourcePolicyExpression,
+			id:       "a0b1c2d3-e4f5-4a6b-8c7d-8e9f0a1b2c3d",
+			raw:      map[string]interface{}{"name": "foo", "expression": "return True"},
+		},
+		"user": {
+			resource: resourceUser,
+			id:       "5",
+			raw:      map[string]interface{}{"username": "foo"},
+		},
+		// In merge mode, the object is retrieved before it's updated
+		"group_merge": {
+			resource: resourceGroup,
+			id:       "a0b1c2d3-e4f5-4a6b-8c7d-8e9f0a1b2c3d",
+			raw:      map[string]interface{}{"name": "foo", "attributes_mode": attributesModeMerge},
+		},
+		"user_merge": {
+			resource: resourceUser,
+			id:       "5",
+			raw:      map[string]interface{}{"username": "foo", "attributes_mode": attributesModeMerge},
+		},
+		"outpost": {
+			resource: resourceOutpost,
+			id:       "a0b1c2d3-e4f5-4a6b-8c7d-8e9f0a1b2c3d",
+			raw:      map[string]interface{}{"name": "foo", "protocol_providers": []interface{}{1}},
+		},
+	}
+	for _, tc := range []struct {
+		operation string
+		call      func(r *schema.Resource) func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics
+		severity  diag.Severity
+		summary   string
+		removed   bool
+	}{
+		{
+			operation: "read",
+			call: func(r *schema.Resource) func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
+				return r.ReadContext
+			},
+			severity: diag.Warning,
+			summary:  "Object not found, removing from state",
+			removed:  true,
+		},
+		{
+			operation: "update",
+			call: func(r *schema.Resource) func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
+				return r.UpdateContext
+			},
+			severity: diag.Error,
+			summary:  "Object was deleted outside of Terraform",
+			removed:  false,
+		},
+		{
+			operation: "delete",
+			call: func(r *schema.Resource) func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
+				return r.DeleteContext
+			},
+			removed: true,
+		},
+	} {
+		for name, res := range resources {
+			t.Run(name+"_"+tc.operation, func(t *testing.T) {
+				r := res.resource()
+				d := schema.TestResourceDataRaw(t, r.Schema, res.raw)
+				d.SetId(res.id)
+				diags := tc.call(r)(context.Background(), d, c)
+				if tc.summary == "" {
+					assert.Empty(t, diags)
+				} else {
+					assert.Len(t, diags, 1)
+					assert.Equal(t, tc.severity, diags[0].Severity)
+					assert.Equal(t, tc.summary, diags[0].Summary)
+				}
+				if tc.removed {
+					assert.Equal(t, "", d.Id())
+				} else {
+					assert.Equal(t, res.id, d.Id())
+				}
+			})
+		}
+	}
+
+	// A 404 of another request than the one for the object itself is a regular error
+	r := resourceOutpost()
+	d := schema.TestResourceDataRaw(t, r.Schema, resources["outpost"].raw)
+	d.SetId(resources["outpost"].id)
+	diags := r.UpdateContext(context.Background(), d, testAPIClient(t, notFound))
+	assert.True(t, diags.HasError())
+	assert.Contains(t, diags[0].Summary, "HTTP Error '404 Not Found'")
+	assert.Equal(t, resources["outpost"].id, d.Id())
+
+	// A data source that doesn't find its object fails instead of silently returning nothing
+	d = dataSourceCertificateKeyPair().TestResourceData()
+	assert.NoError(t, d.Set("name", "foo"))
+	diags = dataSourceCertificateKeyPair().ReadContext(context.Background(), d, c)
+	assert.True(t, diags.HasError())
+}
+
+func Test_defaultTimeouts(t *testing.T) {
+	for name, r := range Provider("test", false).ResourcesMap {
+		assert.NotNil(t, r.Timeouts, name)
+		assert.Equal(t, defaultTimeout, *r.Timeouts.Create, name)
+		assert.Equal(t, defaultTimeout, *r.Timeouts.Delete, name)
+	}
+}
+
+func Test_contextCancellation(t *testing.T) {
+	block := make(chan struct{})
+	defer close(block)
+	c := testAPIClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-block:
+		case <-r.Context().Done():
+		}
+	}))
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	r := resourceOutpost()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{"name": "foo"})
+	diags := r.CreateContext(ctx, d, c)
+	assert.True(t, diags.HasError())
+	assert.Contains(t, diags[0].Summary, "context deadline exceeded")
+}
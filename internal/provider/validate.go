@@ -0,0 +1,25 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// validateEnum Plan-time validation of a string attribute against the allowed values of an API enum,
+// for example `validateEnum(api.AllowedUserTypeEnumEnumValues)`
+func validateEnum[T ~string](allowed []T) schema.SchemaValidateDiagFunc {
+	values := make([]string, len(allowed))
+	for i, v := range allowed {
+		values[i] = string(v)
+	}
+	return validation.ToDiagFunc(validation.StringInSlice(values, false))
+}
+
+// validateIntEnum Plan-time validation of an integer attribute against the allowed values of an API enum
+func validateIntEnum[T ~int32](allowed []T) schema.SchemaValidateDiagFunc {
+	values := make([]int, len(allowed))
+	for i, v := range allowed {
+		values[i] = int(v)
+	}
+	return validation.ToDiagFunc(validation.IntInSlice(values))
+}
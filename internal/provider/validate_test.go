@@ -0,0 +1,59 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	api "goauthentik.io/api/v3"
+)
+
+func Test_validateEnum(t *testing.T) {
+	f := validateEnum(api.AllowedUserTypeEnumEnumValues)
+	assert.False(t, f("internal", cty.Path{}).HasError())
+	diags := f("internl", cty.Path{})
+	assert.True(t, diags.HasError())
+	assert.Contains(t, diags[0].Summary, "internal")
+
+	f = validateIntEnum(api.AllowedDigitsEnumEnumValues)
+	assert.False(t, f(6, cty.Path{}).HasError())
+	assert.True(t, f(7, cty.Path{}).HasError())
+}
+
+func Test_validateEnum_Defaults(t *testing.T) {
+	for name, r := range Provider("test", false).ResourcesMap {
+		for key, s := range r.Schema {
+			if s.ValidateDiagFunc == nil || s.Default == nil {
+				continue
+			}
+			// Defaults are often typed enum constants, which Terraform passes on as plain strings
+			def := s.Default
+			if s.Type == schema.TypeString {
+				def = fmt.Sprint(def)
+			}
+			diags := s.ValidateDiagFunc(def, cty.GetAttrPath(key))
+			assert.False(t, diags.HasError(), "%s.%s: %v", name, key, diags)
+		}
+	}
+}
+
+func Test_validateEnum_Config(t *testing.T) {
+	p := Provider("test", false)
+	for _, tc := range []struct {
+		resource string
+		raw      map[string]interface{}
+		valid    bool
+	}{
+		{"authentik_flow", map[string]interface{}{"name": "foo", "title": "foo", "slug": "foo", "designation": "authentication"}, true},
+		{"authentik_flow", map[string]interface{}{"name": "foo", "title": "foo", "slug": "foo", "designation": "authentification"}, false},
+		{"authentik_stage_password", map[string]interface{}{"name": "foo", "backends": []interface{}{"authentik.core.auth.InbuiltBackend"}}, true},
+		{"authentik_stage_password", map[string]interface{}{"name": "foo", "backends": []interface{}{"authentik.core.auth.Inbuilt"}}, false},
+		{"authentik_stage_authenticator_totp", map[string]interface{}{"name": "foo", "digits": 7}, false},
+	} {
+		diags := p.ResourcesMap[tc.resource].Validate(terraform.NewResourceConfigRaw(tc.raw))
+		assert.Equal(t, !tc.valid, diags.HasError(), "%s: %v", tc.resource, diags)
+	}
+}
@@ -0,0 +1,92 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// parseVersion Parse an authentik version like `2023.8.3` or `2023.10.0-rc1` into its numeric parts
+func parseVersion(v string) ([3]int, error) {
+	parts := [3]int{}
+	v = strings.SplitN(v, "-", 2)[0]
+	for i, p := range strings.SplitN(v, ".", 3) {
+		n, err := strconv.Atoi(p)
+		if err != nil {
+			return parts, fmt.Errorf("invalid version %s", v)
+		}
+		parts[i] = n
+	}
+	return parts, nil
+}
+
+// versionAtLeast Check if version is greater than or equal to minimum
+func versionAtLeast(version string, minimum string) (bool, error) {
+	v, err := parseVersion(version)
+	if err != nil {
+		return false, err
+	}
+	m, err := parseVersion(minimum)
+	if err != nil {
+		return false, err
+	}
+	for i := range v {
+		if v[i] != m[i] {
+			return v[i] > m[i], nil
+		}
+	}
+	return true, nil
+}
+
+// customizeDiffVersion Fail the plan if the server is older than the minimum version required by the
+// resource, or older than the version required by any attribute that is set in the configuration.
+// minimum can be empty if the resource itself is supported by all versions.
+func customizeDiffVersion(minimum string, attributes map[string]string) schema.CustomizeDiffFunc {
+	return func(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+		c, ok := m.(*APIClient)
+		// Version detection failed, so we can't gate anything
+		if !ok || c.version == "" {
+			return nil
+		}
+		if minimum != "" {
+			ok, err := versionAtLeast(c.version, minimum)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				return fmt.Errorf("this resource requires authentik >= %s, but the server is running %s", minimum, c.version)
+			}
+		}
+
+		config := d.GetRawConfig()
+		if config.IsNull() || !config.IsKnown() {
+			return nil
+		}
+		keys := make([]string, 0, len(attributes))
+		for key := range attributes {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		unsupported := []string{}
+		for _, key := range keys {
+			if !config.Type().HasAttribute(key) || config.GetAttr(key).IsNull() {
+				continue
+			}
+			ok, err := versionAtLeast(c.version, attributes[key])
+			if err != nil {
+				return err
+			}
+			if !ok {
+				unsupported = append(unsupported, fmt.Sprintf("`%s` requires authentik >= %s", key, attributes[key]))
+			}
+		}
+		if len(unsupported) > 0 {
+			return fmt.Errorf("%s, but the server is running %s", strings.Join(unsupported, ", "), c.version)
+		}
+		return nil
+	}
+}
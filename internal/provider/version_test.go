@@ -0,0 +1,92 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_versionAtLeast(t *testing.T) {
+	for _, tc := range []struct {
+		version  string
+		minimum  string
+		expected bool
+	}{
+		{"2023.8.3", "2023.8", true},
+		{"2023.8.0", "2023.8", true},
+		{"2023.6.1", "2023.8", false},
+		{"2023.10.0", "2023.8", true},
+		{"2024.1.0-rc1", "2023.10", true},
+		{"2023.10.0-rc1", "2023.10", true},
+		{"2022.12.3", "2023.1", false},
+	} {
+		ok, err := versionAtLeast(tc.version, tc.minimum)
+		assert.NoError(t, err)
+		assert.Equal(t, tc.expected, ok, "%s >= %s", tc.version, tc.minimum)
+	}
+	_, err := versionAtLeast("main", "2023.8")
+	assert.Error(t, err)
+}
+
+func testVersionResource(minimum string) *schema.Resource {
+	return &schema.Resource{
+		CustomizeDiff: customizeDiffVersion(minimum, map[string]string{
+			"new": "2023.8",
+		}),
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"new": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+		},
+	}
+}
+
+func testVersionDiff(r *schema.Resource, version string, config map[string]cty.Value) error {
+	raw := map[string]interface{}{}
+	for k, v := range config {
+		raw[k] = v.AsString()
+	}
+	state := &terraform.InstanceState{
+		ID:        "foo",
+		RawConfig: cty.ObjectVal(config),
+	}
+	_, err := r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(raw), &APIClient{version: version})
+	return err
+}
+
+func Test_customizeDiffVersion(t *testing.T) {
+	r := testVersionResource("2023.4")
+	err := testVersionDiff(r, "2023.3.1", map[string]cty.Value{"name": cty.StringVal("foo")})
+	assert.ErrorContains(t, err, "requires authentik >= 2023.4")
+
+	err = testVersionDiff(r, "2023.4.0", map[string]cty.Value{"name": cty.StringVal("foo")})
+	assert.NoError(t, err)
+
+	err = testVersionDiff(r, "2023.6.0", map[string]cty.Value{
+		"name": cty.StringVal("foo"),
+		"new":  cty.StringVal("bar"),
+	})
+	assert.ErrorContains(t, err, "`new` requires authentik >= 2023.8")
+
+	err = testVersionDiff(r, "2023.8.3", map[string]cty.Value{
+		"name": cty.StringVal("foo"),
+		"new":  cty.StringVal("bar"),
+	})
+	assert.NoError(t, err)
+
+	// Without a known server version nothing is checked
+	err = testVersionDiff(r, "", map[string]cty.Value{
+		"name": cty.StringVal("foo"),
+		"new":  cty.StringVal("bar"),
+	})
+	assert.NoError(t, err)
+}
@@ -1,8 +1,10 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"fmt"
+	"os"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/plugin"
@@ -23,9 +25,15 @@ var (
 func main() {
 	var debugMode bool
 	var versionMode bool
+	var exportDir string
+	var exportManaged bool
+	var blueprintFile string
 
 	flag.BoolVar(&debugMode, "debug", false, "set to true to run the provider with support for debuggers like delve")
 	flag.BoolVar(&versionMode, "version", false, "Show version and exit")
+	flag.StringVar(&exportDir, "export", "", "Export the objects of the authentik instance configured with AUTHENTIK_URL and AUTHENTIK_TOKEN as Terraform configuration into this directory and exit")
+	flag.BoolVar(&exportManaged, "export-managed", false, "Include objects managed by authentik itself in the export")
+	flag.StringVar(&blueprintFile, "convert-blueprint", "", "Convert the authentik blueprint in this file to Terraform configuration, which is written to stdout, and exit")
 	flag.Parse()
 
 	opts := &plugin.ServeOpts{
@@ -40,5 +48,31 @@ func main() {
 		return
 	}
 
+	if exportDir != "" {
+		err := provider.Export(context.Background(), version, provider.ExportOptions{
+			Dir:            exportDir,
+			IncludeManaged: exportManaged,
+			Log:            os.Stderr,
+		})
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	if blueprintFile != "" {
+		in, err := os.ReadFile(blueprintFile)
+		if err == nil {
+			err = provider.ConvertBlueprint(in, os.Stdout, os.Stderr)
+		}
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	plugin.Serve(opts)
+	provider.FlushTelemetry()
 }